@@ -3,3 +3,30 @@
 {{- range .Values.nsxt.dnsServers }}"{{ . }}", {{ end }}
 {{- end }}
 {{- end -}}
+
+{{- define "vsphere-infra.searchDomains" }}
+{{- if .Values.dhcp.searchDomains }}
+{{- range .Values.dhcp.searchDomains }}"{{ . }}", {{ end }}
+{{- end }}
+{{- end -}}
+
+{{- define "vsphere-infra.tags" }}
+{{- if .Values.shootUID }}
+  tag {
+    scope = "shoot-uid"
+    tag   = "${var.nsx_tag_shoot_uid}"
+  }
+{{- end }}
+{{- if .Values.gardenID }}
+  tag {
+    scope = "garden"
+    tag   = "${var.nsx_tag_garden}"
+  }
+{{- end }}
+{{- range .Values.tags }}
+  tag {
+    scope = "{{ .scope }}"
+    tag   = "{{ .tag }}"
+  }
+{{- end }}
+{{- end -}}
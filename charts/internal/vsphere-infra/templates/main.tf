@@ -13,7 +13,7 @@ provider "nsxt" {
 
 # Inputs
 variable "nsx_full_cluster_name" {
-    default = "{{ .Values.nsxt.namePrefix }}_{{ .Values.clusterName }}"
+    default = "{{ .Values.nsxt.fullClusterName | default (printf "%s_%s" .Values.nsxt.namePrefix .Values.clusterName) }}"
 }
 variable "nsx_tag_scope" {
     default = "nameprefix"
@@ -24,8 +24,18 @@ variable "nsx_tag" {
 variable "nsx_tag_shoot" {
     default = "{{ required "clusterName is required" .Values.clusterName }}"
 }
+{{- if .Values.shootUID }}
+variable "nsx_tag_shoot_uid" {
+    default = "{{ .Values.shootUID }}"
+}
+{{- end }}
+{{- if .Values.gardenID }}
+variable "nsx_tag_garden" {
+    default = "{{ .Values.gardenID }}"
+}
+{{- end }}
 variable "nsx_t1_router_name" {
-    default = "{{ .Values.nsxt.namePrefix }}_{{ .Values.clusterName }}"
+    default = "{{ .Values.nsxt.fullClusterName | default (printf "%s_%s" .Values.nsxt.namePrefix .Values.clusterName) }}"
 }
 variable "nsx_networks_worker" {
     default = "{{ required "networks.worker is required" .Values.networks.worker }}"
@@ -47,8 +57,26 @@ data "nsxt_logical_tier0_router" "cluster" {
 }
 
 data "nsxt_edge_cluster" "cluster" {
+{{- if .Values.nsxt.edgeClusterID }}
+    id = "{{ .Values.nsxt.edgeClusterID }}"
+{{- else }}
     display_name = "{{ required "vsphere.nsxt.edgeCluster is required" .Values.nsxt.edgeCluster }}"
+{{- end }}
+}
+
+{{- if .Values.dhcp.edgeCluster }}
+
+data "nsxt_edge_cluster" "dhcp" {
+    display_name = "{{ .Values.dhcp.edgeCluster }}"
+}
+{{- end }}
+
+{{- range $i, $profile := .Values.nsxt.switchingProfiles }}
+
+data "nsxt_switching_profile" "switch_{{ $i }}" {
+    display_name = "{{ $profile }}"
 }
+{{- end }}
 
 data "nsxt_ip_pool" "snat_pool" {
     display_name = "{{ required "vsphere.nsxt.snatIpPool is required" .Values.nsxt.snatIpPool }}"
@@ -71,7 +99,14 @@ resource "nsxt_logical_switch" "switch" {
   description = "logical switch for gardener cluster"
   display_name = "${local.network_name}"
   transport_zone_id = "${data.nsxt_transport_zone.cluster.id}"
-  replication_mode = "MTEP"
+  replication_mode = "{{ .Values.nsxt.switchReplicationMode | default "MTEP" }}"
+  {{- range $i, $profile := .Values.nsxt.switchingProfiles }}
+
+  switching_profile_id {
+    key   = "${data.nsxt_switching_profile.switch_{{ $i }}.resource_type}"
+    value = "${data.nsxt_switching_profile.switch_{{ $i }}.id}"
+  }
+  {{- end }}
 
   tag {
     scope = "${var.nsx_tag_scope}"
@@ -81,6 +116,7 @@ resource "nsxt_logical_switch" "switch" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 resource "nsxt_logical_router_link_port_on_tier0" "external" {
@@ -96,6 +132,7 @@ resource "nsxt_logical_router_link_port_on_tier0" "external" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 resource "nsxt_logical_tier1_router" "router" {
@@ -118,6 +155,7 @@ resource "nsxt_logical_tier1_router" "router" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 resource "nsxt_logical_router_link_port_on_tier1" "router" {
@@ -134,6 +172,7 @@ resource "nsxt_logical_router_link_port_on_tier1" "router" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 # Create a switchport on our logical switch
@@ -151,6 +190,7 @@ resource "nsxt_logical_port" "switch" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 # Create downlink port on the T1 router and connect it to the switchport we created earlier
@@ -169,6 +209,7 @@ resource "nsxt_logical_router_downlink_port" "downlink_port" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 # IP address of all nodes for SNAT
@@ -196,13 +237,20 @@ resource "nsxt_nat_rule" "cluster-snat" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
+{{- if not .Values.dhcp.disabled }}
+
 # install a DHCP server
 resource "nsxt_dhcp_server_profile" "profile" {
   display_name     = "${var.nsx_full_cluster_name}"
   description      = "dhcp server profile of ${var.nsx_full_cluster_name}"
+  {{- if .Values.dhcp.edgeCluster }}
+  edge_cluster_id = "${data.nsxt_edge_cluster.dhcp.id}"
+  {{- else }}
   edge_cluster_id = "${data.nsxt_edge_cluster.cluster.id}"
+  {{- end }}
 
   tag {
     scope = "${var.nsx_tag_scope}"
@@ -212,14 +260,22 @@ resource "nsxt_dhcp_server_profile" "profile" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 resource "nsxt_logical_dhcp_server" "dhcpserver" {
   display_name     = "${var.nsx_full_cluster_name}"
   description      = "logical dhcp server of ${var.nsx_full_cluster_name}"
   dhcp_profile_id  = "${nsxt_dhcp_server_profile.profile.id}"
+  {{- if .Values.dhcp.serverIP }}
+  dhcp_server_ip   = "{{ .Values.dhcp.serverIP }}${var.nsx_networks_worker_suffix}"
+  {{- else }}
   dhcp_server_ip   = "${cidrhost(var.nsx_networks_worker, 2)}${var.nsx_networks_worker_suffix}"
+  {{- end }}
   gateway_ip       = "${cidrhost(var.nsx_networks_worker, 1)}"
+  {{- if .Values.dhcp.domainName }}
+  domain_name      = "{{ .Values.dhcp.domainName }}"
+  {{- end }}
 
   {{- if .Values.nsxt.dnsServers }}
   dns_name_servers = [{{- include "vsphere-infra.dnsServers" . | trimSuffix ", " }}]
@@ -227,6 +283,38 @@ resource "nsxt_logical_dhcp_server" "dhcpserver" {
   dns_name_servers = []
   {{- end }}
 
+  {{- if .Values.networks.workerMTU }}
+
+  dhcp_generic_option {
+    code   = "26" # 26 = interface MTU
+    values = ["{{ .Values.networks.workerMTU }}"]
+  }
+  {{- end }}
+
+  {{- range .Values.dhcp.staticRoutes }}
+
+  dhcp_option_121 {
+    network  = "{{ required "dhcp.staticRoutes[].destination is required" .destination }}"
+    next_hop = "{{ required "dhcp.staticRoutes[].nextHop is required" .nextHop }}"
+  }
+  {{- end }}
+
+  {{- if .Values.dhcp.searchDomains }}
+
+  dhcp_generic_option {
+    code   = "119" # 119 = domain search list
+    values = [{{- include "vsphere-infra.searchDomains" . | trimSuffix ", " }}]
+  }
+  {{- end }}
+
+  {{- range .Values.dhcp.options }}
+
+  dhcp_generic_option {
+    code   = "{{ required "dhcp.options[].code is required" .code }}"
+    values = [{{ range $i, $value := .values }}{{ if $i }}, {{ end }}"{{ $value }}"{{ end }}]
+  }
+  {{- end }}
+
   tag {
     scope = "${var.nsx_tag_scope}"
     tag = "${var.nsx_tag}"
@@ -235,6 +323,14 @@ resource "nsxt_logical_dhcp_server" "dhcpserver" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
+  {{- if .Values.dhcp.adoptRenames }}
+
+  lifecycle {
+    # keep the display name if the DHCP server was renamed externally
+    ignore_changes = ["display_name"]
+  }
+  {{- end }}
 }
 
 resource "nsxt_logical_dhcp_port" "dhcpserver" {
@@ -252,26 +348,37 @@ resource "nsxt_logical_dhcp_port" "dhcpserver" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
 
 resource "nsxt_dhcp_server_ip_pool" "dhcp_pool" {
   display_name           = "${var.nsx_full_cluster_name}"
   description            = "dhcp ip pool for ${var.nsx_full_cluster_name}"
   logical_dhcp_server_id = "${nsxt_logical_dhcp_server.dhcpserver.id}"
+  {{- if .Values.dhcp.clientGateway }}
+  gateway_ip             = "{{ .Values.dhcp.clientGateway }}"
+  {{- else }}
   gateway_ip             = "${nsxt_logical_dhcp_server.dhcpserver.gateway_ip}"
+  {{- end }}
   lease_time             = 7200
   error_threshold        = 98
   warning_threshold      = 70
 
+  {{- if .Values.dhcp.ranges }}
+  {{- range .Values.dhcp.ranges }}
+
+  ip_range {
+    start = "{{ required "dhcp.ranges[].start is required" .start }}"
+    end   = "{{ required "dhcp.ranges[].end is required" .end }}"
+  }
+  {{- end }}
+  {{- else }}
+
   ip_range {
     start = "${cidrhost(var.nsx_networks_worker, 10)}"
     end   = "${cidrhost(var.nsx_networks_worker, -1)}"
   }
-
-  #dhcp_generic_option {
-  #  code   = "119" # 119 = domain search list
-  #  values = ["my.domain.com"]
-  #}
+  {{- end }}
 
   tag {
     scope = "${var.nsx_tag_scope}"
@@ -281,7 +388,9 @@ resource "nsxt_dhcp_server_ip_pool" "dhcp_pool" {
     scope = "shoot"
     tag   = "${var.nsx_tag_shoot}"
   }
+  {{- include "vsphere-infra.tags" . }}
 }
+{{- end }}
 
 
 //=====================================================================
@@ -300,3 +409,29 @@ output "logical_switch_id" {
   value = "${nsxt_logical_switch.switch.id}"
 }
 
+output "router_downlink_ip" {
+  value = "${nsxt_logical_router_downlink_port.downlink_port.ip_address}"
+}
+
+{{- if not .Values.dhcp.disabled }}
+
+output "dhcp_profile_id" {
+  value = "${nsxt_dhcp_server_profile.profile.id}"
+}
+
+output "dhcp_server_id" {
+  value = "${nsxt_logical_dhcp_server.dhcpserver.id}"
+}
+
+output "dhcp_port_id" {
+  value = "${nsxt_logical_dhcp_port.dhcpserver.id}"
+}
+
+output "dhcp_ip_pool_id" {
+  value = "${nsxt_dhcp_server_ip_pool.dhcp_pool.id}"
+}
+
+output "dhcp_gateway_ip" {
+  value = "${nsxt_dhcp_server_ip_pool.dhcp_pool.gateway_ip}"
+}
+{{- end }}
@@ -39,6 +39,137 @@ type ControllerConfiguration struct {
 	// HealthCheckConfig is the config for the health check controller
 	// +optional
 	HealthCheckConfig *healthcheckconfigv1alpha1.HealthCheckConfig `json:"healthCheckConfig,omitempty"`
+	// Infrastructure is the configuration of the infrastructure controller.
+	// +optional
+	Infrastructure InfrastructureControllerConfiguration `json:"infrastructure,omitempty"`
+}
+
+// InfrastructureControllerConfiguration is the configuration of the infrastructure controller.
+type InfrastructureControllerConfiguration struct {
+	// TransportZonePreCheck specifies whether the transport zone of the region is checked to be of overlay type
+	// using the NSX-T API before the infrastructure is reconciled.
+	// +optional
+	TransportZonePreCheck bool `json:"transportZonePreCheck,omitempty"`
+	// SNATIPPoolPreCheck specifies whether the SNAT IP pool of the region is checked for free addresses
+	// and a subnet of the address family of the worker network using the NSX-T API before the infrastructure is reconciled. Unless ExcludeSNATIPPoolFromDHCP is set,
+	// it is also checked that the SNAT IP pool does not overlap the worker network.
+	// +optional
+	SNATIPPoolPreCheck bool `json:"snatIPPoolPreCheck,omitempty"`
+	// EdgeClusterPreCheck specifies whether the edge cluster of the region is checked to have members which are up
+	// using the NSX-T API before the infrastructure is reconciled.
+	// +optional
+	EdgeClusterPreCheck bool `json:"edgeClusterPreCheck,omitempty"`
+	// DHCPLeasePreCheck specifies whether the active leases of the DHCP server of the worker network are checked to be
+	// within the DHCP ranges using the NSX-T API before the infrastructure is reconciled, so that resizing the worker
+	// network or changing the DHCP ranges does not take away addresses which are still in use.
+	// +optional
+	DHCPLeasePreCheck bool `json:"dhcpLeasePreCheck,omitempty"`
+	// ExcludeSNATIPPoolFromDHCP specifies whether the allocation ranges of the SNAT IP pool of the region are looked up
+	// using the NSX-T API and excluded from the DHCP ranges of the worker network. This is only needed if the SNAT IP pool
+	// overlaps with worker networks.
+	// +optional
+	ExcludeSNATIPPoolFromDHCP bool `json:"excludeSNATIPPoolFromDHCP,omitempty"`
+	// DeleteDHCPOnHibernation specifies whether the DHCP server of the worker network is deleted while the shoot is hibernated.
+	// The network itself is kept, and the DHCP server is recreated when the shoot wakes up.
+	// +optional
+	DeleteDHCPOnHibernation bool `json:"deleteDHCPOnHibernation,omitempty"`
+	// DHCPDeletionGracePeriod is the optional time the deletion of the infrastructure is deferred after it was requested,
+	// so that nodes which are still drained can renew their DHCP leases. As the DHCP server is deleted together with the
+	// network, the deletion of the whole infrastructure is deferred.
+	// +optional
+	DHCPDeletionGracePeriod *metav1.Duration `json:"dhcpDeletionGracePeriod,omitempty"`
+	// DHCPEdgeClusterFailover specifies whether the DHCP server profile of the worker network is rebound to the standby
+	// edge cluster of the region if no member of its edge cluster is up. It is rebound to the edge cluster as soon as
+	// one of its members is up again.
+	// +optional
+	DHCPEdgeClusterFailover bool `json:"dhcpEdgeClusterFailover,omitempty"`
+	// AdoptDHCPServerRenames specifies whether the display name of the DHCP server of the worker network is kept
+	// if it was changed externally, e.g. in the NSX-T UI. By default, the name is restored on the next reconciliation.
+	// +optional
+	AdoptDHCPServerRenames bool `json:"adoptDHCPServerRenames,omitempty"`
+	// DHCPPoolUtilization configures events on the Infrastructure resource which are emitted if the utilization
+	// of the DHCP IP pool of the worker network crosses certain levels. The utilization is read using the NSX-T API
+	// periodically, see DHCPPoolPollInterval.
+	// +optional
+	DHCPPoolUtilization *DHCPPoolUtilizationConfiguration `json:"dhcpPoolUtilization,omitempty"`
+	// DHCPPoolMetrics specifies whether the utilization of the DHCP IP pool of the worker network is exposed as
+	// Prometheus metrics by cluster. The utilization is read using the NSX-T API periodically, see DHCPPoolPollInterval.
+	// +optional
+	DHCPPoolMetrics bool `json:"dhcpPoolMetrics,omitempty"`
+	// DHCPPoolPollInterval is the interval in which the utilization of the DHCP IP pools of the worker networks
+	// is read for the DHCPPoolUtilization events and the DHCPPoolMetrics. It defaults to 5 minutes.
+	// +optional
+	DHCPPoolPollInterval *metav1.Duration `json:"dhcpPoolPollInterval,omitempty"`
+	// NSXTUserAgent is the User-Agent header of the requests to the NSX-T API. It defaults to
+	// gardener-extension-provider-vsphere/<version>, followed by the garden identity if known.
+	// +optional
+	NSXTUserAgent string `json:"nsxtUserAgent,omitempty"`
+	// SuppressInsecureSSLWarning specifies whether the warning is suppressed which is logged once per region if the
+	// TLS certificate of its NSX-T manager is not verified (nsxtInsecureSSL in the cloud profile).
+	// +optional
+	SuppressInsecureSSLWarning bool `json:"suppressInsecureSSLWarning,omitempty"`
+	// NSXTObjectAnnotations specifies whether the ids of the NSX-T objects of a shoot are recorded as annotations
+	// vsphere.provider.extensions.gardener.cloud/nsxt-<object>-id on its Infrastructure resource.
+	// +optional
+	NSXTObjectAnnotations bool `json:"nsxtObjectAnnotations,omitempty"`
+	// MaxConcurrentNSXTOperations is the maximum number of Terraform runs creating, updating or deleting NSX-T objects
+	// of all shoots at the same time. It protects a shared NSX-T manager from overload, e.g. when many infrastructures
+	// are reconciled after a restart of the controller. There is no limit by default.
+	// +optional
+	MaxConcurrentNSXTOperations int `json:"maxConcurrentNSXTOperations,omitempty"`
+	// MinNamePrefixLength is the minimum length of the name prefix of the cloud profile. The name prefix is part
+	// of the names of all NSX-T objects, so a short one increases the risk of collisions on a shared NSX-T manager.
+	// +optional
+	MinNamePrefixLength int `json:"minNamePrefixLength,omitempty"`
+	// DNSServerProbe configures a probe of the DNS servers handed out by the DHCP server of the worker network before
+	// the infrastructure is reconciled. Unreachable DNS servers only emit a warning event on the Infrastructure resource.
+	// +optional
+	DNSServerProbe *DNSServerProbeConfiguration `json:"dnsServerProbe,omitempty"`
+	// NSXTTimeouts are the timeouts of the requests to the NSX-T API.
+	// +optional
+	NSXTTimeouts *NSXTTimeoutsConfiguration `json:"nsxtTimeouts,omitempty"`
+	// PrivateWorkerNetworkCheck configures a check that the worker network of the shoot is within the private
+	// networks of RFC 1918 before the infrastructure is reconciled.
+	// +optional
+	PrivateWorkerNetworkCheck *PrivateWorkerNetworkCheckConfiguration `json:"privateWorkerNetworkCheck,omitempty"`
+}
+
+// PrivateWorkerNetworkCheckConfiguration is the configuration of the private worker network check.
+type PrivateWorkerNetworkCheckConfiguration struct {
+	// Enforce specifies whether the reconciliation of the infrastructure fails for a public worker network.
+	// Otherwise, only a warning event is emitted on the Infrastructure resource.
+	// +optional
+	Enforce bool `json:"enforce,omitempty"`
+}
+
+// NSXTTimeoutsConfiguration is the configuration of the timeouts of the requests to the NSX-T API.
+type NSXTTimeoutsConfiguration struct {
+	// Dial is the maximum time to establish a connection to the NSX-T manager. It defaults to 10 seconds.
+	// +optional
+	Dial *metav1.Duration `json:"dial,omitempty"`
+	// TLSHandshake is the maximum time of the TLS handshake with the NSX-T manager. It defaults to 10 seconds.
+	// +optional
+	TLSHandshake *metav1.Duration `json:"tlsHandshake,omitempty"`
+	// Request is the maximum time of a request including reading the response. It defaults to 30 seconds.
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+}
+
+// DNSServerProbeConfiguration is the configuration of the DNS server probe.
+type DNSServerProbeConfiguration struct {
+	// Timeout is the maximum time to wait for a connection to TCP port 53 of a DNS server. It defaults to 2 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DHCPPoolUtilizationConfiguration is the configuration of the DHCP IP pool utilization events.
+type DHCPPoolUtilizationConfiguration struct {
+	// Levels are the utilization percentages which emit an event when crossed.
+	Levels []int `json:"levels"`
+	// Hysteresis is the number of percentage points the utilization must fall below a level
+	// before it is considered to be crossed downwards again.
+	// +optional
+	Hysteresis int `json:"hysteresis,omitempty"`
 }
 
 // ETCD is an etcd configuration.
@@ -27,6 +27,7 @@ import (
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config/v1alpha1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -50,6 +51,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DHCPPoolUtilizationConfiguration)(nil), (*config.DHCPPoolUtilizationConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DHCPPoolUtilizationConfiguration_To_config_DHCPPoolUtilizationConfiguration(a.(*DHCPPoolUtilizationConfiguration), b.(*config.DHCPPoolUtilizationConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DHCPPoolUtilizationConfiguration)(nil), (*DHCPPoolUtilizationConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DHCPPoolUtilizationConfiguration_To_v1alpha1_DHCPPoolUtilizationConfiguration(a.(*config.DHCPPoolUtilizationConfiguration), b.(*DHCPPoolUtilizationConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSServerProbeConfiguration)(nil), (*config.DNSServerProbeConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DNSServerProbeConfiguration_To_config_DNSServerProbeConfiguration(a.(*DNSServerProbeConfiguration), b.(*config.DNSServerProbeConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DNSServerProbeConfiguration)(nil), (*DNSServerProbeConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DNSServerProbeConfiguration_To_v1alpha1_DNSServerProbeConfiguration(a.(*config.DNSServerProbeConfiguration), b.(*DNSServerProbeConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ETCD)(nil), (*config.ETCD)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ETCD_To_config_ETCD(a.(*ETCD), b.(*config.ETCD), scope)
 	}); err != nil {
@@ -70,6 +91,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InfrastructureControllerConfiguration)(nil), (*config.InfrastructureControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InfrastructureControllerConfiguration_To_config_InfrastructureControllerConfiguration(a.(*InfrastructureControllerConfiguration), b.(*config.InfrastructureControllerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InfrastructureControllerConfiguration)(nil), (*InfrastructureControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InfrastructureControllerConfiguration_To_v1alpha1_InfrastructureControllerConfiguration(a.(*config.InfrastructureControllerConfiguration), b.(*InfrastructureControllerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NSXTTimeoutsConfiguration)(nil), (*config.NSXTTimeoutsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NSXTTimeoutsConfiguration_To_config_NSXTTimeoutsConfiguration(a.(*NSXTTimeoutsConfiguration), b.(*config.NSXTTimeoutsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NSXTTimeoutsConfiguration)(nil), (*NSXTTimeoutsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NSXTTimeoutsConfiguration_To_v1alpha1_NSXTTimeoutsConfiguration(a.(*config.NSXTTimeoutsConfiguration), b.(*NSXTTimeoutsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrivateWorkerNetworkCheckConfiguration)(nil), (*config.PrivateWorkerNetworkCheckConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrivateWorkerNetworkCheckConfiguration_To_config_PrivateWorkerNetworkCheckConfiguration(a.(*PrivateWorkerNetworkCheckConfiguration), b.(*config.PrivateWorkerNetworkCheckConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PrivateWorkerNetworkCheckConfiguration)(nil), (*PrivateWorkerNetworkCheckConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PrivateWorkerNetworkCheckConfiguration_To_v1alpha1_PrivateWorkerNetworkCheckConfiguration(a.(*config.PrivateWorkerNetworkCheckConfiguration), b.(*PrivateWorkerNetworkCheckConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -80,6 +131,9 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfigurat
 		return err
 	}
 	out.HealthCheckConfig = (*healthcheckconfig.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
+	if err := Convert_v1alpha1_InfrastructureControllerConfiguration_To_config_InfrastructureControllerConfiguration(&in.Infrastructure, &out.Infrastructure, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -95,6 +149,9 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 		return err
 	}
 	out.HealthCheckConfig = (*healthcheckconfigv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
+	if err := Convert_config_InfrastructureControllerConfiguration_To_v1alpha1_InfrastructureControllerConfiguration(&in.Infrastructure, &out.Infrastructure, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -103,6 +160,48 @@ func Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(
 	return autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in, out, s)
 }
 
+func autoConvert_v1alpha1_DHCPPoolUtilizationConfiguration_To_config_DHCPPoolUtilizationConfiguration(in *DHCPPoolUtilizationConfiguration, out *config.DHCPPoolUtilizationConfiguration, s conversion.Scope) error {
+	out.Levels = *(*[]int)(unsafe.Pointer(&in.Levels))
+	out.Hysteresis = in.Hysteresis
+	return nil
+}
+
+// Convert_v1alpha1_DHCPPoolUtilizationConfiguration_To_config_DHCPPoolUtilizationConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_DHCPPoolUtilizationConfiguration_To_config_DHCPPoolUtilizationConfiguration(in *DHCPPoolUtilizationConfiguration, out *config.DHCPPoolUtilizationConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DHCPPoolUtilizationConfiguration_To_config_DHCPPoolUtilizationConfiguration(in, out, s)
+}
+
+func autoConvert_config_DHCPPoolUtilizationConfiguration_To_v1alpha1_DHCPPoolUtilizationConfiguration(in *config.DHCPPoolUtilizationConfiguration, out *DHCPPoolUtilizationConfiguration, s conversion.Scope) error {
+	out.Levels = *(*[]int)(unsafe.Pointer(&in.Levels))
+	out.Hysteresis = in.Hysteresis
+	return nil
+}
+
+// Convert_config_DHCPPoolUtilizationConfiguration_To_v1alpha1_DHCPPoolUtilizationConfiguration is an autogenerated conversion function.
+func Convert_config_DHCPPoolUtilizationConfiguration_To_v1alpha1_DHCPPoolUtilizationConfiguration(in *config.DHCPPoolUtilizationConfiguration, out *DHCPPoolUtilizationConfiguration, s conversion.Scope) error {
+	return autoConvert_config_DHCPPoolUtilizationConfiguration_To_v1alpha1_DHCPPoolUtilizationConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_DNSServerProbeConfiguration_To_config_DNSServerProbeConfiguration(in *DNSServerProbeConfiguration, out *config.DNSServerProbeConfiguration, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha1_DNSServerProbeConfiguration_To_config_DNSServerProbeConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_DNSServerProbeConfiguration_To_config_DNSServerProbeConfiguration(in *DNSServerProbeConfiguration, out *config.DNSServerProbeConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DNSServerProbeConfiguration_To_config_DNSServerProbeConfiguration(in, out, s)
+}
+
+func autoConvert_config_DNSServerProbeConfiguration_To_v1alpha1_DNSServerProbeConfiguration(in *config.DNSServerProbeConfiguration, out *DNSServerProbeConfiguration, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_config_DNSServerProbeConfiguration_To_v1alpha1_DNSServerProbeConfiguration is an autogenerated conversion function.
+func Convert_config_DNSServerProbeConfiguration_To_v1alpha1_DNSServerProbeConfiguration(in *config.DNSServerProbeConfiguration, out *DNSServerProbeConfiguration, s conversion.Scope) error {
+	return autoConvert_config_DNSServerProbeConfiguration_To_v1alpha1_DNSServerProbeConfiguration(in, out, s)
+}
+
 func autoConvert_v1alpha1_ETCD_To_config_ETCD(in *ETCD, out *config.ETCD, s conversion.Scope) error {
 	if err := Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(&in.Storage, &out.Storage, s); err != nil {
 		return err
@@ -150,3 +249,105 @@ func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStora
 func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
+
+func autoConvert_v1alpha1_InfrastructureControllerConfiguration_To_config_InfrastructureControllerConfiguration(in *InfrastructureControllerConfiguration, out *config.InfrastructureControllerConfiguration, s conversion.Scope) error {
+	out.TransportZonePreCheck = in.TransportZonePreCheck
+	out.SNATIPPoolPreCheck = in.SNATIPPoolPreCheck
+	out.EdgeClusterPreCheck = in.EdgeClusterPreCheck
+	out.DHCPLeasePreCheck = in.DHCPLeasePreCheck
+	out.ExcludeSNATIPPoolFromDHCP = in.ExcludeSNATIPPoolFromDHCP
+	out.DeleteDHCPOnHibernation = in.DeleteDHCPOnHibernation
+	out.DHCPDeletionGracePeriod = (*v1.Duration)(unsafe.Pointer(in.DHCPDeletionGracePeriod))
+	out.DHCPEdgeClusterFailover = in.DHCPEdgeClusterFailover
+	out.AdoptDHCPServerRenames = in.AdoptDHCPServerRenames
+	out.DHCPPoolUtilization = (*config.DHCPPoolUtilizationConfiguration)(unsafe.Pointer(in.DHCPPoolUtilization))
+	out.DHCPPoolMetrics = in.DHCPPoolMetrics
+	out.DHCPPoolPollInterval = (*v1.Duration)(unsafe.Pointer(in.DHCPPoolPollInterval))
+	out.NSXTUserAgent = in.NSXTUserAgent
+	out.SuppressInsecureSSLWarning = in.SuppressInsecureSSLWarning
+	out.NSXTObjectAnnotations = in.NSXTObjectAnnotations
+	out.MaxConcurrentNSXTOperations = in.MaxConcurrentNSXTOperations
+	out.MinNamePrefixLength = in.MinNamePrefixLength
+	out.DNSServerProbe = (*config.DNSServerProbeConfiguration)(unsafe.Pointer(in.DNSServerProbe))
+	out.NSXTTimeouts = (*config.NSXTTimeoutsConfiguration)(unsafe.Pointer(in.NSXTTimeouts))
+	out.PrivateWorkerNetworkCheck = (*config.PrivateWorkerNetworkCheckConfiguration)(unsafe.Pointer(in.PrivateWorkerNetworkCheck))
+	return nil
+}
+
+// Convert_v1alpha1_InfrastructureControllerConfiguration_To_config_InfrastructureControllerConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_InfrastructureControllerConfiguration_To_config_InfrastructureControllerConfiguration(in *InfrastructureControllerConfiguration, out *config.InfrastructureControllerConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InfrastructureControllerConfiguration_To_config_InfrastructureControllerConfiguration(in, out, s)
+}
+
+func autoConvert_config_InfrastructureControllerConfiguration_To_v1alpha1_InfrastructureControllerConfiguration(in *config.InfrastructureControllerConfiguration, out *InfrastructureControllerConfiguration, s conversion.Scope) error {
+	out.TransportZonePreCheck = in.TransportZonePreCheck
+	out.SNATIPPoolPreCheck = in.SNATIPPoolPreCheck
+	out.EdgeClusterPreCheck = in.EdgeClusterPreCheck
+	out.DHCPLeasePreCheck = in.DHCPLeasePreCheck
+	out.ExcludeSNATIPPoolFromDHCP = in.ExcludeSNATIPPoolFromDHCP
+	out.DeleteDHCPOnHibernation = in.DeleteDHCPOnHibernation
+	out.DHCPDeletionGracePeriod = (*v1.Duration)(unsafe.Pointer(in.DHCPDeletionGracePeriod))
+	out.DHCPEdgeClusterFailover = in.DHCPEdgeClusterFailover
+	out.AdoptDHCPServerRenames = in.AdoptDHCPServerRenames
+	out.DHCPPoolUtilization = (*DHCPPoolUtilizationConfiguration)(unsafe.Pointer(in.DHCPPoolUtilization))
+	out.DHCPPoolMetrics = in.DHCPPoolMetrics
+	out.DHCPPoolPollInterval = (*v1.Duration)(unsafe.Pointer(in.DHCPPoolPollInterval))
+	out.NSXTUserAgent = in.NSXTUserAgent
+	out.SuppressInsecureSSLWarning = in.SuppressInsecureSSLWarning
+	out.NSXTObjectAnnotations = in.NSXTObjectAnnotations
+	out.MaxConcurrentNSXTOperations = in.MaxConcurrentNSXTOperations
+	out.MinNamePrefixLength = in.MinNamePrefixLength
+	out.DNSServerProbe = (*DNSServerProbeConfiguration)(unsafe.Pointer(in.DNSServerProbe))
+	out.NSXTTimeouts = (*NSXTTimeoutsConfiguration)(unsafe.Pointer(in.NSXTTimeouts))
+	out.PrivateWorkerNetworkCheck = (*PrivateWorkerNetworkCheckConfiguration)(unsafe.Pointer(in.PrivateWorkerNetworkCheck))
+	return nil
+}
+
+// Convert_config_InfrastructureControllerConfiguration_To_v1alpha1_InfrastructureControllerConfiguration is an autogenerated conversion function.
+func Convert_config_InfrastructureControllerConfiguration_To_v1alpha1_InfrastructureControllerConfiguration(in *config.InfrastructureControllerConfiguration, out *InfrastructureControllerConfiguration, s conversion.Scope) error {
+	return autoConvert_config_InfrastructureControllerConfiguration_To_v1alpha1_InfrastructureControllerConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_NSXTTimeoutsConfiguration_To_config_NSXTTimeoutsConfiguration(in *NSXTTimeoutsConfiguration, out *config.NSXTTimeoutsConfiguration, s conversion.Scope) error {
+	out.Dial = (*v1.Duration)(unsafe.Pointer(in.Dial))
+	out.TLSHandshake = (*v1.Duration)(unsafe.Pointer(in.TLSHandshake))
+	out.Request = (*v1.Duration)(unsafe.Pointer(in.Request))
+	return nil
+}
+
+// Convert_v1alpha1_NSXTTimeoutsConfiguration_To_config_NSXTTimeoutsConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_NSXTTimeoutsConfiguration_To_config_NSXTTimeoutsConfiguration(in *NSXTTimeoutsConfiguration, out *config.NSXTTimeoutsConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NSXTTimeoutsConfiguration_To_config_NSXTTimeoutsConfiguration(in, out, s)
+}
+
+func autoConvert_config_NSXTTimeoutsConfiguration_To_v1alpha1_NSXTTimeoutsConfiguration(in *config.NSXTTimeoutsConfiguration, out *NSXTTimeoutsConfiguration, s conversion.Scope) error {
+	out.Dial = (*v1.Duration)(unsafe.Pointer(in.Dial))
+	out.TLSHandshake = (*v1.Duration)(unsafe.Pointer(in.TLSHandshake))
+	out.Request = (*v1.Duration)(unsafe.Pointer(in.Request))
+	return nil
+}
+
+// Convert_config_NSXTTimeoutsConfiguration_To_v1alpha1_NSXTTimeoutsConfiguration is an autogenerated conversion function.
+func Convert_config_NSXTTimeoutsConfiguration_To_v1alpha1_NSXTTimeoutsConfiguration(in *config.NSXTTimeoutsConfiguration, out *NSXTTimeoutsConfiguration, s conversion.Scope) error {
+	return autoConvert_config_NSXTTimeoutsConfiguration_To_v1alpha1_NSXTTimeoutsConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrivateWorkerNetworkCheckConfiguration_To_config_PrivateWorkerNetworkCheckConfiguration(in *PrivateWorkerNetworkCheckConfiguration, out *config.PrivateWorkerNetworkCheckConfiguration, s conversion.Scope) error {
+	out.Enforce = in.Enforce
+	return nil
+}
+
+// Convert_v1alpha1_PrivateWorkerNetworkCheckConfiguration_To_config_PrivateWorkerNetworkCheckConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_PrivateWorkerNetworkCheckConfiguration_To_config_PrivateWorkerNetworkCheckConfiguration(in *PrivateWorkerNetworkCheckConfiguration, out *config.PrivateWorkerNetworkCheckConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrivateWorkerNetworkCheckConfiguration_To_config_PrivateWorkerNetworkCheckConfiguration(in, out, s)
+}
+
+func autoConvert_config_PrivateWorkerNetworkCheckConfiguration_To_v1alpha1_PrivateWorkerNetworkCheckConfiguration(in *config.PrivateWorkerNetworkCheckConfiguration, out *PrivateWorkerNetworkCheckConfiguration, s conversion.Scope) error {
+	out.Enforce = in.Enforce
+	return nil
+}
+
+// Convert_config_PrivateWorkerNetworkCheckConfiguration_To_v1alpha1_PrivateWorkerNetworkCheckConfiguration is an autogenerated conversion function.
+func Convert_config_PrivateWorkerNetworkCheckConfiguration_To_v1alpha1_PrivateWorkerNetworkCheckConfiguration(in *config.PrivateWorkerNetworkCheckConfiguration, out *PrivateWorkerNetworkCheckConfiguration, s conversion.Scope) error {
+	return autoConvert_config_PrivateWorkerNetworkCheckConfiguration_To_v1alpha1_PrivateWorkerNetworkCheckConfiguration(in, out, s)
+}
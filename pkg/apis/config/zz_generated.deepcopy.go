@@ -22,6 +22,7 @@ package config
 
 import (
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
 )
@@ -41,6 +42,7 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(healthcheckconfig.HealthCheckConfig)
 		**out = **in
 	}
+	in.Infrastructure.DeepCopyInto(&out.Infrastructure)
 	return
 }
 
@@ -62,6 +64,48 @@ func (in *ControllerConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPPoolUtilizationConfiguration) DeepCopyInto(out *DHCPPoolUtilizationConfiguration) {
+	*out = *in
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPPoolUtilizationConfiguration.
+func (in *DHCPPoolUtilizationConfiguration) DeepCopy() *DHCPPoolUtilizationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPPoolUtilizationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSServerProbeConfiguration) DeepCopyInto(out *DNSServerProbeConfiguration) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSServerProbeConfiguration.
+func (in *DNSServerProbeConfiguration) DeepCopy() *DNSServerProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSServerProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ETCD) DeepCopyInto(out *ETCD) {
 	*out = *in
@@ -109,3 +153,96 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfrastructureControllerConfiguration) DeepCopyInto(out *InfrastructureControllerConfiguration) {
+	*out = *in
+	if in.DHCPDeletionGracePeriod != nil {
+		in, out := &in.DHCPDeletionGracePeriod, &out.DHCPDeletionGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DHCPPoolUtilization != nil {
+		in, out := &in.DHCPPoolUtilization, &out.DHCPPoolUtilization
+		*out = new(DHCPPoolUtilizationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DHCPPoolPollInterval != nil {
+		in, out := &in.DHCPPoolPollInterval, &out.DHCPPoolPollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DNSServerProbe != nil {
+		in, out := &in.DNSServerProbe, &out.DNSServerProbe
+		*out = new(DNSServerProbeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NSXTTimeouts != nil {
+		in, out := &in.NSXTTimeouts, &out.NSXTTimeouts
+		*out = new(NSXTTimeoutsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateWorkerNetworkCheck != nil {
+		in, out := &in.PrivateWorkerNetworkCheck, &out.PrivateWorkerNetworkCheck
+		*out = new(PrivateWorkerNetworkCheckConfiguration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfrastructureControllerConfiguration.
+func (in *InfrastructureControllerConfiguration) DeepCopy() *InfrastructureControllerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(InfrastructureControllerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSXTTimeoutsConfiguration) DeepCopyInto(out *NSXTTimeoutsConfiguration) {
+	*out = *in
+	if in.Dial != nil {
+		in, out := &in.Dial, &out.Dial
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TLSHandshake != nil {
+		in, out := &in.TLSHandshake, &out.TLSHandshake
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSXTTimeoutsConfiguration.
+func (in *NSXTTimeoutsConfiguration) DeepCopy() *NSXTTimeoutsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NSXTTimeoutsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateWorkerNetworkCheckConfiguration) DeepCopyInto(out *PrivateWorkerNetworkCheckConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateWorkerNetworkCheckConfiguration.
+func (in *PrivateWorkerNetworkCheckConfiguration) DeepCopy() *PrivateWorkerNetworkCheckConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateWorkerNetworkCheckConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+)
+
+// DefaultNameTemplate is the template of the base name of the NSX-T objects of a shoot if the cloud profile
+// does not specify one.
+const DefaultNameTemplate = "{{ .NamePrefix }}_{{ .Namespace }}"
+
+// NameTemplateData are the variables available in the name template of the cloud profile.
+type NameTemplateData struct {
+	// NamePrefix is the name prefix of the cloud profile.
+	NamePrefix string
+	// Namespace is the namespace of the shoot in the seed.
+	Namespace string
+}
+
+// FullClusterName returns the base name of the NSX-T objects of the shoot with the given namespace in the seed.
+// It is rendered from the name template of the given cloud profile config, or from the DefaultNameTemplate.
+// The NSX-T objects are named by appending a role suffix to it.
+func FullClusterName(cloudProfileConfig *api.CloudProfileConfig, namespace string) (string, error) {
+	nameTemplate := DefaultNameTemplate
+	if cloudProfileConfig.NameTemplate != nil {
+		nameTemplate = *cloudProfileConfig.NameTemplate
+	}
+
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("could not parse name template %q: %v", nameTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, NameTemplateData{NamePrefix: cloudProfileConfig.NamePrefix, Namespace: namespace}); err != nil {
+		return "", fmt.Errorf("could not render name template %q: %v", nameTemplate, err)
+	}
+	return buf.String(), nil
+}
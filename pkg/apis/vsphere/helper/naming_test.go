@@ -0,0 +1,54 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper_test
+
+import (
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/helper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Naming", func() {
+	Describe("#FullClusterName", func() {
+		var cloudProfileConfig *api.CloudProfileConfig
+
+		BeforeEach(func() {
+			cloudProfileConfig = &api.CloudProfileConfig{NamePrefix: "gardener"}
+		})
+
+		It("should use the default template", func() {
+			Expect(FullClusterName(cloudProfileConfig, "shoot--foo--bar")).To(Equal("gardener_shoot--foo--bar"))
+		})
+
+		It("should use a custom template", func() {
+			nameTemplate := `k8s-{{ .Namespace }}-{{ .NamePrefix | printf "%.3s" }}`
+			cloudProfileConfig.NameTemplate = &nameTemplate
+
+			Expect(FullClusterName(cloudProfileConfig, "shoot--foo--bar")).To(Equal("k8s-shoot--foo--bar-gar"))
+		})
+
+		It("should fail for invalid templates", func() {
+			for _, nameTemplate := range []string{"{{ .Namespace", "{{ .Shoot }}"} {
+				nameTemplate := nameTemplate
+				cloudProfileConfig.NameTemplate = &nameTemplate
+
+				_, err := FullClusterName(cloudProfileConfig, "shoot--foo--bar")
+				Expect(err).To(HaveOccurred(), nameTemplate)
+			}
+		})
+	})
+})
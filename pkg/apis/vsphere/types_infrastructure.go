@@ -23,6 +23,64 @@ import (
 // InfrastructureConfig infrastructure configuration resource
 type InfrastructureConfig struct {
 	metav1.TypeMeta
+
+	// WorkerSegmentMTU is the optional MTU of the worker network segment handed out to the nodes.
+	WorkerSegmentMTU *int
+	// DHCPDomainName is the optional domain name handed out to the nodes by the DHCP server.
+	DHCPDomainName *string
+	// DHCPSearchDomains is the optional domain search list handed out to the nodes by the DHCP server.
+	DHCPSearchDomains []string
+	// DHCPStaticRoutes are the optional classless static routes handed out to the nodes by the DHCP server.
+	DHCPStaticRoutes []DHCPStaticRoute
+	// DHCPOptions are optional additional options handed out to the nodes by the DHCP server, e.g. vendor-specific
+	// information (DHCP option 43).
+	DHCPOptions []DHCPOption
+	// DHCPRanges are the optional address ranges of the worker network handed out by the DHCP server.
+	// If not set, the addresses from the 10th to the last address of the worker network are handed out.
+	DHCPRanges []AddressRange
+	// DHCPExcludedRanges are the optional address ranges of the worker network which are not handed out by the DHCP server.
+	DHCPExcludedRanges []AddressRange
+	// DHCPServerIP is the optional IP address of the DHCP server in the worker network.
+	// If not set, the second address of the worker network is used.
+	DHCPServerIP *string
+	// DHCPClientGateway is the optional default gateway handed out to the nodes by the DHCP server, e.g. the address of
+	// a load balancer or firewall in the worker network. If not set, the gateway of the worker network is handed out.
+	DHCPClientGateway *string
+	// NSXTTags are optional additional tags of all NSX-T objects created for the shoot, e.g. to attribute resource
+	// consumption for tag based quota policies.
+	NSXTTags []NSXTTag
+}
+
+// NSXTTag is a tag of an NSX-T object.
+type NSXTTag struct {
+	// Scope is the scope of the tag.
+	Scope string
+	// Tag is the value of the tag.
+	Tag string
+}
+
+// AddressRange is an inclusive range of IPv4 addresses.
+type AddressRange struct {
+	// Start is the first address of the range.
+	Start string
+	// End is the last address of the range.
+	End string
+}
+
+// DHCPStaticRoute is a classless static route handed out by the DHCP server (DHCP option 121).
+type DHCPStaticRoute struct {
+	// Destination is the destination network in CIDR notation.
+	Destination string
+	// NextHop is the IP address of the router for the destination network.
+	NextHop string
+}
+
+// DHCPOption is a generic option handed out by the DHCP server.
+type DHCPOption struct {
+	// Code is the code of the DHCP option.
+	Code int
+	// Values are the values of the DHCP option.
+	Values []string
 }
 
 // VsphereConfig holds information about vSphere resources to use.
@@ -49,6 +107,8 @@ type ZoneConfig struct {
 	Datastore string
 	// DatastoreCluster is the datastore cluster to store the cloned machine VM. Either Datastore or DatastoreCluster must be specified
 	DatastoreCluster string
+	// Folder is the folder name to store the cloned machine VM. If set, it overwrites the Folder of the VsphereConfig
+	Folder string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -62,4 +122,9 @@ type InfrastructureStatus struct {
 	LogicalRouterId string
 
 	VsphereConfig VsphereConfig
+
+	// WorkerNetwork is the worker network of the shoot the infrastructure was reconciled with.
+	WorkerNetwork string
+	// DHCPServerIP is the DHCP server IP of the InfrastructureConfig the infrastructure was reconciled with.
+	DHCPServerIP *string
 }
@@ -27,6 +27,11 @@ type CloudProfileConfig struct {
 	metav1.TypeMeta `json:",inline"`
 	// NamePrefix is used for naming NSX-T resources
 	NamePrefix string `json:"namePrefix"`
+	// NameTemplate is the optional Go template of the base name of the NSX-T objects of a shoot, which is suffixed
+	// with the role of the object. It can use the variables .NamePrefix and .Namespace (of the shoot in the seed),
+	// and defaults to "{{ .NamePrefix }}_{{ .Namespace }}".
+	// +optional
+	NameTemplate *string `json:"nameTemplate,omitempty"`
 	// Folder is the vSphere folder name to store the cloned machine VM (worker nodes)
 	Folder string `json:"folder"`
 	// Regions is the specification of regions and zones topology
@@ -38,6 +43,10 @@ type CloudProfileConfig struct {
 	FailureDomainLabels *FailureDomainLabels `json:"failureDomainLabels,omitempty"`
 	// DNSServers is a list of IPs of DNS servers used while creating subnets.
 	DNSServers []string `json:"dnsServers"`
+	// ResourcePoolPathFormat is the optional format the resource pools of the zones must have. It is either Name
+	// for plain resource pool names or InventoryPath for absolute inventory paths. If not set, both are allowed.
+	// +optional
+	ResourcePoolPathFormat *string `json:"resourcePoolPathFormat,omitempty"`
 	// MachineImages is the list of machine images that are understood by the controller. It maps
 	// logical names and versions to provider-specific identifiers.
 	MachineImages []MachineImages `json:"machineImages"`
@@ -70,12 +79,38 @@ type RegionSpec struct {
 	NSXTHost string `json:"nsxtHost"`
 	// NSXTInsecureSSL is a flag if insecure HTTPS is allowed for NSXTHost
 	NSXTInsecureSSL bool `json:"nsxtInsecureSSL"`
+	// NSXTReadHost is an optional secondary NSX-T manager node used for reads (lookups and health checks) of the
+	// provider itself. Terraform always uses NSXTHost. If the secondary is unreachable, NSXTHost is used instead.
+	// +optional
+	NSXTReadHost *string `json:"nsxtReadHost,omitempty"`
 	// TransportZone is the NSX-T transport zone
 	TransportZone string `json:"transportZone"`
 	// LogicalTier0Router is the NSX-T logical tier 0 router
 	LogicalTier0Router string `json:"logicalTier0Router"`
 	// EdgeCluster is the NSX-T edge cluster
 	EdgeCluster string `json:"edgeCluster"`
+	// EdgeClusterID is the optional id of the NSX-T edge cluster. If set, the edge cluster is looked up by id
+	// instead of by the display name EdgeCluster.
+	// +optional
+	EdgeClusterID *string `json:"edgeClusterID,omitempty"`
+	// StandbyEdgeCluster is an optional NSX-T edge cluster the DHCP server profiles fail over to if no member of
+	// EdgeCluster is up. Failover must be enabled in the infrastructure controller configuration.
+	// +optional
+	StandbyEdgeCluster *string `json:"standbyEdgeCluster,omitempty"`
+	// SwitchReplicationMode is the optional replication mode of the logical switches of the worker networks for
+	// broadcast, unknown unicast and multicast traffic, e.g. of the DHCP requests. It is either MTEP or SOURCE and
+	// defaults to MTEP.
+	// +optional
+	SwitchReplicationMode *string `json:"switchReplicationMode,omitempty"`
+	// SwitchingProfiles are the optional names of NSX-T switching profiles, e.g. for QoS or MAC discovery, which are
+	// bound to the logical switches of the worker networks instead of the default profiles of their types.
+	// +optional
+	SwitchingProfiles []string `json:"switchingProfiles,omitempty"`
+	// MaxWorkerSegmentMTU is the optional largest MTU the logical switches of the worker networks carry, as given by
+	// the MTU of the uplink profile of the transport zone minus the overlay overhead. The MTU of the switches cannot be
+	// set per switch, so a larger workerSegmentMTU of the InfrastructureConfig of a shoot is rejected.
+	// +optional
+	MaxWorkerSegmentMTU *int `json:"maxWorkerSegmentMTU,omitempty"`
 	// SNATIPPool is the NSX-T IP pool to allocate the SNAT ip address
 	SNATIPPool string `json:"snatIPPool"`
 
@@ -89,6 +124,10 @@ type RegionSpec struct {
 	// DatastoreCluster is the vSphere  datastore cluster to store the cloned machine VM. Either Datastore or DatastoreCluster must be specified at region or zones level.
 	// +optional
 	DatastoreCluster *string `json:"datastoreCluster,omitempty"`
+	// Folder is the optional vSphere folder name to store the cloned machine VM (worker nodes) of this region.
+	// If provided, it overwrites the global Folder of the CloudProfileConfig
+	// +optional
+	Folder *string `json:"folder,omitempty"`
 
 	// Zones is the list of zone specifications of the region.
 	Zones []ZoneSpec `json:"zones"`
@@ -136,6 +175,15 @@ type ZoneSpec struct {
 	// DatastoreCluster is the vSphere  datastore cluster to store the cloned machine VM. Either Datastore or DatastoreCluster must be specified at region or zones level.
 	// +optional
 	DatastoreCluster *string `json:"datastoreCluster,omitempty"`
+	// Folder is the optional vSphere folder name to store the cloned machine VM (worker nodes) of this zone.
+	// If provided, it overwrites the Folder of the region and the global Folder of the CloudProfileConfig
+	// +optional
+	Folder *string `json:"folder,omitempty"`
+	// DNSServers is a optional list of IPs of DNS servers local to this zone. As all zones of a shoot share the
+	// DHCP server of the worker network, they are only used if all zones of the shoot's workers have the same DNSServers.
+	// Otherwise, the DNSServers of the region or the global DNSServers of the CloudProfileConfig are used.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
 }
 
 // Constraints is an object containing constraints for the shoots.
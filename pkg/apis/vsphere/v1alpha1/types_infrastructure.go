@@ -24,6 +24,74 @@ import (
 // InfrastructureConfig infrastructure configuration resource
 type InfrastructureConfig struct {
 	metav1.TypeMeta `json:",inline"`
+
+	// WorkerSegmentMTU is the optional MTU of the worker network segment handed out to the nodes.
+	// +optional
+	WorkerSegmentMTU *int `json:"workerSegmentMTU,omitempty"`
+	// DHCPDomainName is the optional domain name handed out to the nodes by the DHCP server.
+	// +optional
+	DHCPDomainName *string `json:"dhcpDomainName,omitempty"`
+	// DHCPSearchDomains is the optional domain search list handed out to the nodes by the DHCP server.
+	// +optional
+	DHCPSearchDomains []string `json:"dhcpSearchDomains,omitempty"`
+	// DHCPStaticRoutes are the optional classless static routes handed out to the nodes by the DHCP server.
+	// +optional
+	DHCPStaticRoutes []DHCPStaticRoute `json:"dhcpStaticRoutes,omitempty"`
+	// DHCPOptions are optional additional options handed out to the nodes by the DHCP server, e.g. vendor-specific
+	// information (DHCP option 43).
+	// +optional
+	DHCPOptions []DHCPOption `json:"dhcpOptions,omitempty"`
+	// DHCPRanges are the optional address ranges of the worker network handed out by the DHCP server.
+	// If not set, the addresses from the 10th to the last address of the worker network are handed out.
+	// +optional
+	DHCPRanges []AddressRange `json:"dhcpRanges,omitempty"`
+	// DHCPExcludedRanges are the optional address ranges of the worker network which are not handed out by the DHCP server.
+	// +optional
+	DHCPExcludedRanges []AddressRange `json:"dhcpExcludedRanges,omitempty"`
+	// DHCPServerIP is the optional IP address of the DHCP server in the worker network.
+	// If not set, the second address of the worker network is used.
+	// +optional
+	DHCPServerIP *string `json:"dhcpServerIP,omitempty"`
+	// DHCPClientGateway is the optional default gateway handed out to the nodes by the DHCP server, e.g. the address of
+	// a load balancer or firewall in the worker network. If not set, the gateway of the worker network is handed out.
+	// +optional
+	DHCPClientGateway *string `json:"dhcpClientGateway,omitempty"`
+	// NSXTTags are optional additional tags of all NSX-T objects created for the shoot, e.g. to attribute resource
+	// consumption for tag based quota policies.
+	// +optional
+	NSXTTags []NSXTTag `json:"nsxtTags,omitempty"`
+}
+
+// NSXTTag is a tag of an NSX-T object.
+type NSXTTag struct {
+	// Scope is the scope of the tag.
+	Scope string `json:"scope"`
+	// Tag is the value of the tag.
+	Tag string `json:"tag"`
+}
+
+// AddressRange is an inclusive range of IPv4 addresses.
+type AddressRange struct {
+	// Start is the first address of the range.
+	Start string `json:"start"`
+	// End is the last address of the range.
+	End string `json:"end"`
+}
+
+// DHCPStaticRoute is a classless static route handed out by the DHCP server (DHCP option 121).
+type DHCPStaticRoute struct {
+	// Destination is the destination network in CIDR notation.
+	Destination string `json:"destination"`
+	// NextHop is the IP address of the router for the destination network.
+	NextHop string `json:"nextHop"`
+}
+
+// DHCPOption is a generic option handed out by the DHCP server.
+type DHCPOption struct {
+	// Code is the code of the DHCP option.
+	Code int `json:"code"`
+	// Values are the values of the DHCP option.
+	Values []string `json:"values"`
 }
 
 // VsphereConfig holds information about vSphere resources to use.
@@ -50,6 +118,8 @@ type ZoneConfig struct {
 	Datastore string `json:"datastore,omitempty"`
 	// DatastoreCluster is the datastore  cluster to store the cloned machine VM. Either Datastore or DatastoreCluster must be specified
 	DatastoreCluster string `json:"datastoreCluster,omitempty"`
+	// Folder is the folder name to store the cloned machine VM. If set, it overwrites the Folder of the VsphereConfig
+	Folder string `json:"folder,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -63,4 +133,11 @@ type InfrastructureStatus struct {
 	LogicalRouterId string `json:"logicalRouterId"`
 
 	VsphereConfig VsphereConfig `json:"vsphereConfig"`
+
+	// WorkerNetwork is the worker network of the shoot the infrastructure was reconciled with.
+	// +optional
+	WorkerNetwork string `json:"workerNetwork,omitempty"`
+	// DHCPServerIP is the DHCP server IP of the InfrastructureConfig the infrastructure was reconciled with.
+	// +optional
+	DHCPServerIP *string `json:"dhcpServerIP,omitempty"`
 }
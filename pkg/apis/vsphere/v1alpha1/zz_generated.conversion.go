@@ -35,6 +35,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AddressRange)(nil), (*vsphere.AddressRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AddressRange_To_vsphere_AddressRange(a.(*AddressRange), b.(*vsphere.AddressRange), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*vsphere.AddressRange)(nil), (*AddressRange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_vsphere_AddressRange_To_v1alpha1_AddressRange(a.(*vsphere.AddressRange), b.(*AddressRange), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CPLoadBalancerClass)(nil), (*vsphere.CPLoadBalancerClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CPLoadBalancerClass_To_vsphere_CPLoadBalancerClass(a.(*CPLoadBalancerClass), b.(*vsphere.CPLoadBalancerClass), scope)
 	}); err != nil {
@@ -85,6 +95,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DHCPOption)(nil), (*vsphere.DHCPOption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DHCPOption_To_vsphere_DHCPOption(a.(*DHCPOption), b.(*vsphere.DHCPOption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*vsphere.DHCPOption)(nil), (*DHCPOption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_vsphere_DHCPOption_To_v1alpha1_DHCPOption(a.(*vsphere.DHCPOption), b.(*DHCPOption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DHCPStaticRoute)(nil), (*vsphere.DHCPStaticRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DHCPStaticRoute_To_vsphere_DHCPStaticRoute(a.(*DHCPStaticRoute), b.(*vsphere.DHCPStaticRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*vsphere.DHCPStaticRoute)(nil), (*DHCPStaticRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_vsphere_DHCPStaticRoute_To_v1alpha1_DHCPStaticRoute(a.(*vsphere.DHCPStaticRoute), b.(*DHCPStaticRoute), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FailureDomainLabels)(nil), (*vsphere.FailureDomainLabels)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_FailureDomainLabels_To_vsphere_FailureDomainLabels(a.(*FailureDomainLabels), b.(*vsphere.FailureDomainLabels), scope)
 	}); err != nil {
@@ -165,6 +195,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NSXTTag)(nil), (*vsphere.NSXTTag)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NSXTTag_To_vsphere_NSXTTag(a.(*NSXTTag), b.(*vsphere.NSXTTag), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*vsphere.NSXTTag)(nil), (*NSXTTag)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_vsphere_NSXTTag_To_v1alpha1_NSXTTag(a.(*vsphere.NSXTTag), b.(*NSXTTag), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RegionSpec)(nil), (*vsphere.RegionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RegionSpec_To_vsphere_RegionSpec(a.(*RegionSpec), b.(*vsphere.RegionSpec), scope)
 	}); err != nil {
@@ -218,6 +258,28 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_AddressRange_To_vsphere_AddressRange(in *AddressRange, out *vsphere.AddressRange, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	return nil
+}
+
+// Convert_v1alpha1_AddressRange_To_vsphere_AddressRange is an autogenerated conversion function.
+func Convert_v1alpha1_AddressRange_To_vsphere_AddressRange(in *AddressRange, out *vsphere.AddressRange, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AddressRange_To_vsphere_AddressRange(in, out, s)
+}
+
+func autoConvert_vsphere_AddressRange_To_v1alpha1_AddressRange(in *vsphere.AddressRange, out *AddressRange, s conversion.Scope) error {
+	out.Start = in.Start
+	out.End = in.End
+	return nil
+}
+
+// Convert_vsphere_AddressRange_To_v1alpha1_AddressRange is an autogenerated conversion function.
+func Convert_vsphere_AddressRange_To_v1alpha1_AddressRange(in *vsphere.AddressRange, out *AddressRange, s conversion.Scope) error {
+	return autoConvert_vsphere_AddressRange_To_v1alpha1_AddressRange(in, out, s)
+}
+
 func autoConvert_v1alpha1_CPLoadBalancerClass_To_vsphere_CPLoadBalancerClass(in *CPLoadBalancerClass, out *vsphere.CPLoadBalancerClass, s conversion.Scope) error {
 	out.Name = in.Name
 	out.IPPoolName = (*string)(unsafe.Pointer(in.IPPoolName))
@@ -262,11 +324,13 @@ func Convert_vsphere_CloudControllerManagerConfig_To_v1alpha1_CloudControllerMan
 
 func autoConvert_v1alpha1_CloudProfileConfig_To_vsphere_CloudProfileConfig(in *CloudProfileConfig, out *vsphere.CloudProfileConfig, s conversion.Scope) error {
 	out.NamePrefix = in.NamePrefix
+	out.NameTemplate = (*string)(unsafe.Pointer(in.NameTemplate))
 	out.Folder = in.Folder
 	out.Regions = *(*[]vsphere.RegionSpec)(unsafe.Pointer(&in.Regions))
 	out.DefaultClassStoragePolicyName = in.DefaultClassStoragePolicyName
 	out.FailureDomainLabels = (*vsphere.FailureDomainLabels)(unsafe.Pointer(in.FailureDomainLabels))
 	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
+	out.ResourcePoolPathFormat = (*string)(unsafe.Pointer(in.ResourcePoolPathFormat))
 	out.MachineImages = *(*[]vsphere.MachineImages)(unsafe.Pointer(&in.MachineImages))
 	if err := Convert_v1alpha1_Constraints_To_vsphere_Constraints(&in.Constraints, &out.Constraints, s); err != nil {
 		return err
@@ -281,11 +345,13 @@ func Convert_v1alpha1_CloudProfileConfig_To_vsphere_CloudProfileConfig(in *Cloud
 
 func autoConvert_vsphere_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *vsphere.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
 	out.NamePrefix = in.NamePrefix
+	out.NameTemplate = (*string)(unsafe.Pointer(in.NameTemplate))
 	out.Folder = in.Folder
 	out.Regions = *(*[]RegionSpec)(unsafe.Pointer(&in.Regions))
 	out.DefaultClassStoragePolicyName = in.DefaultClassStoragePolicyName
 	out.FailureDomainLabels = (*FailureDomainLabels)(unsafe.Pointer(in.FailureDomainLabels))
 	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
+	out.ResourcePoolPathFormat = (*string)(unsafe.Pointer(in.ResourcePoolPathFormat))
 	out.MachineImages = *(*[]MachineImages)(unsafe.Pointer(&in.MachineImages))
 	if err := Convert_vsphere_Constraints_To_v1alpha1_Constraints(&in.Constraints, &out.Constraints, s); err != nil {
 		return err
@@ -346,6 +412,50 @@ func Convert_vsphere_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *vsphe
 	return autoConvert_vsphere_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_DHCPOption_To_vsphere_DHCPOption(in *DHCPOption, out *vsphere.DHCPOption, s conversion.Scope) error {
+	out.Code = in.Code
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_v1alpha1_DHCPOption_To_vsphere_DHCPOption is an autogenerated conversion function.
+func Convert_v1alpha1_DHCPOption_To_vsphere_DHCPOption(in *DHCPOption, out *vsphere.DHCPOption, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DHCPOption_To_vsphere_DHCPOption(in, out, s)
+}
+
+func autoConvert_vsphere_DHCPOption_To_v1alpha1_DHCPOption(in *vsphere.DHCPOption, out *DHCPOption, s conversion.Scope) error {
+	out.Code = in.Code
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_vsphere_DHCPOption_To_v1alpha1_DHCPOption is an autogenerated conversion function.
+func Convert_vsphere_DHCPOption_To_v1alpha1_DHCPOption(in *vsphere.DHCPOption, out *DHCPOption, s conversion.Scope) error {
+	return autoConvert_vsphere_DHCPOption_To_v1alpha1_DHCPOption(in, out, s)
+}
+
+func autoConvert_v1alpha1_DHCPStaticRoute_To_vsphere_DHCPStaticRoute(in *DHCPStaticRoute, out *vsphere.DHCPStaticRoute, s conversion.Scope) error {
+	out.Destination = in.Destination
+	out.NextHop = in.NextHop
+	return nil
+}
+
+// Convert_v1alpha1_DHCPStaticRoute_To_vsphere_DHCPStaticRoute is an autogenerated conversion function.
+func Convert_v1alpha1_DHCPStaticRoute_To_vsphere_DHCPStaticRoute(in *DHCPStaticRoute, out *vsphere.DHCPStaticRoute, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DHCPStaticRoute_To_vsphere_DHCPStaticRoute(in, out, s)
+}
+
+func autoConvert_vsphere_DHCPStaticRoute_To_v1alpha1_DHCPStaticRoute(in *vsphere.DHCPStaticRoute, out *DHCPStaticRoute, s conversion.Scope) error {
+	out.Destination = in.Destination
+	out.NextHop = in.NextHop
+	return nil
+}
+
+// Convert_vsphere_DHCPStaticRoute_To_v1alpha1_DHCPStaticRoute is an autogenerated conversion function.
+func Convert_vsphere_DHCPStaticRoute_To_v1alpha1_DHCPStaticRoute(in *vsphere.DHCPStaticRoute, out *DHCPStaticRoute, s conversion.Scope) error {
+	return autoConvert_vsphere_DHCPStaticRoute_To_v1alpha1_DHCPStaticRoute(in, out, s)
+}
+
 func autoConvert_v1alpha1_FailureDomainLabels_To_vsphere_FailureDomainLabels(in *FailureDomainLabels, out *vsphere.FailureDomainLabels, s conversion.Scope) error {
 	out.Region = in.Region
 	out.Zone = in.Zone
@@ -369,6 +479,16 @@ func Convert_vsphere_FailureDomainLabels_To_v1alpha1_FailureDomainLabels(in *vsp
 }
 
 func autoConvert_v1alpha1_InfrastructureConfig_To_vsphere_InfrastructureConfig(in *InfrastructureConfig, out *vsphere.InfrastructureConfig, s conversion.Scope) error {
+	out.WorkerSegmentMTU = (*int)(unsafe.Pointer(in.WorkerSegmentMTU))
+	out.DHCPDomainName = (*string)(unsafe.Pointer(in.DHCPDomainName))
+	out.DHCPSearchDomains = *(*[]string)(unsafe.Pointer(&in.DHCPSearchDomains))
+	out.DHCPStaticRoutes = *(*[]vsphere.DHCPStaticRoute)(unsafe.Pointer(&in.DHCPStaticRoutes))
+	out.DHCPOptions = *(*[]vsphere.DHCPOption)(unsafe.Pointer(&in.DHCPOptions))
+	out.DHCPRanges = *(*[]vsphere.AddressRange)(unsafe.Pointer(&in.DHCPRanges))
+	out.DHCPExcludedRanges = *(*[]vsphere.AddressRange)(unsafe.Pointer(&in.DHCPExcludedRanges))
+	out.DHCPServerIP = (*string)(unsafe.Pointer(in.DHCPServerIP))
+	out.DHCPClientGateway = (*string)(unsafe.Pointer(in.DHCPClientGateway))
+	out.NSXTTags = *(*[]vsphere.NSXTTag)(unsafe.Pointer(&in.NSXTTags))
 	return nil
 }
 
@@ -378,6 +498,16 @@ func Convert_v1alpha1_InfrastructureConfig_To_vsphere_InfrastructureConfig(in *I
 }
 
 func autoConvert_vsphere_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in *vsphere.InfrastructureConfig, out *InfrastructureConfig, s conversion.Scope) error {
+	out.WorkerSegmentMTU = (*int)(unsafe.Pointer(in.WorkerSegmentMTU))
+	out.DHCPDomainName = (*string)(unsafe.Pointer(in.DHCPDomainName))
+	out.DHCPSearchDomains = *(*[]string)(unsafe.Pointer(&in.DHCPSearchDomains))
+	out.DHCPStaticRoutes = *(*[]DHCPStaticRoute)(unsafe.Pointer(&in.DHCPStaticRoutes))
+	out.DHCPOptions = *(*[]DHCPOption)(unsafe.Pointer(&in.DHCPOptions))
+	out.DHCPRanges = *(*[]AddressRange)(unsafe.Pointer(&in.DHCPRanges))
+	out.DHCPExcludedRanges = *(*[]AddressRange)(unsafe.Pointer(&in.DHCPExcludedRanges))
+	out.DHCPServerIP = (*string)(unsafe.Pointer(in.DHCPServerIP))
+	out.DHCPClientGateway = (*string)(unsafe.Pointer(in.DHCPClientGateway))
+	out.NSXTTags = *(*[]NSXTTag)(unsafe.Pointer(&in.NSXTTags))
 	return nil
 }
 
@@ -393,6 +523,8 @@ func autoConvert_v1alpha1_InfrastructureStatus_To_vsphere_InfrastructureStatus(i
 	if err := Convert_v1alpha1_VsphereConfig_To_vsphere_VsphereConfig(&in.VsphereConfig, &out.VsphereConfig, s); err != nil {
 		return err
 	}
+	out.WorkerNetwork = in.WorkerNetwork
+	out.DHCPServerIP = (*string)(unsafe.Pointer(in.DHCPServerIP))
 	return nil
 }
 
@@ -408,6 +540,8 @@ func autoConvert_vsphere_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(i
 	if err := Convert_vsphere_VsphereConfig_To_v1alpha1_VsphereConfig(&in.VsphereConfig, &out.VsphereConfig, s); err != nil {
 		return err
 	}
+	out.WorkerNetwork = in.WorkerNetwork
+	out.DHCPServerIP = (*string)(unsafe.Pointer(in.DHCPServerIP))
 	return nil
 }
 
@@ -532,19 +666,48 @@ func Convert_vsphere_MachineImages_To_v1alpha1_MachineImages(in *vsphere.Machine
 	return autoConvert_vsphere_MachineImages_To_v1alpha1_MachineImages(in, out, s)
 }
 
+func autoConvert_v1alpha1_NSXTTag_To_vsphere_NSXTTag(in *NSXTTag, out *vsphere.NSXTTag, s conversion.Scope) error {
+	out.Scope = in.Scope
+	out.Tag = in.Tag
+	return nil
+}
+
+// Convert_v1alpha1_NSXTTag_To_vsphere_NSXTTag is an autogenerated conversion function.
+func Convert_v1alpha1_NSXTTag_To_vsphere_NSXTTag(in *NSXTTag, out *vsphere.NSXTTag, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NSXTTag_To_vsphere_NSXTTag(in, out, s)
+}
+
+func autoConvert_vsphere_NSXTTag_To_v1alpha1_NSXTTag(in *vsphere.NSXTTag, out *NSXTTag, s conversion.Scope) error {
+	out.Scope = in.Scope
+	out.Tag = in.Tag
+	return nil
+}
+
+// Convert_vsphere_NSXTTag_To_v1alpha1_NSXTTag is an autogenerated conversion function.
+func Convert_vsphere_NSXTTag_To_v1alpha1_NSXTTag(in *vsphere.NSXTTag, out *NSXTTag, s conversion.Scope) error {
+	return autoConvert_vsphere_NSXTTag_To_v1alpha1_NSXTTag(in, out, s)
+}
+
 func autoConvert_v1alpha1_RegionSpec_To_vsphere_RegionSpec(in *RegionSpec, out *vsphere.RegionSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.VsphereHost = in.VsphereHost
 	out.VsphereInsecureSSL = in.VsphereInsecureSSL
 	out.NSXTHost = in.NSXTHost
 	out.NSXTInsecureSSL = in.NSXTInsecureSSL
+	out.NSXTReadHost = (*string)(unsafe.Pointer(in.NSXTReadHost))
 	out.TransportZone = in.TransportZone
 	out.LogicalTier0Router = in.LogicalTier0Router
 	out.EdgeCluster = in.EdgeCluster
+	out.EdgeClusterID = (*string)(unsafe.Pointer(in.EdgeClusterID))
+	out.StandbyEdgeCluster = (*string)(unsafe.Pointer(in.StandbyEdgeCluster))
+	out.SwitchReplicationMode = (*string)(unsafe.Pointer(in.SwitchReplicationMode))
+	out.SwitchingProfiles = *(*[]string)(unsafe.Pointer(&in.SwitchingProfiles))
+	out.MaxWorkerSegmentMTU = (*int)(unsafe.Pointer(in.MaxWorkerSegmentMTU))
 	out.SNATIPPool = in.SNATIPPool
 	out.Datacenter = (*string)(unsafe.Pointer(in.Datacenter))
 	out.Datastore = (*string)(unsafe.Pointer(in.Datastore))
 	out.DatastoreCluster = (*string)(unsafe.Pointer(in.DatastoreCluster))
+	out.Folder = (*string)(unsafe.Pointer(in.Folder))
 	out.Zones = *(*[]vsphere.ZoneSpec)(unsafe.Pointer(&in.Zones))
 	out.CaFile = (*string)(unsafe.Pointer(in.CaFile))
 	out.Thumbprint = (*string)(unsafe.Pointer(in.Thumbprint))
@@ -564,13 +727,20 @@ func autoConvert_vsphere_RegionSpec_To_v1alpha1_RegionSpec(in *vsphere.RegionSpe
 	out.VsphereInsecureSSL = in.VsphereInsecureSSL
 	out.NSXTHost = in.NSXTHost
 	out.NSXTInsecureSSL = in.NSXTInsecureSSL
+	out.NSXTReadHost = (*string)(unsafe.Pointer(in.NSXTReadHost))
 	out.TransportZone = in.TransportZone
 	out.LogicalTier0Router = in.LogicalTier0Router
 	out.EdgeCluster = in.EdgeCluster
+	out.EdgeClusterID = (*string)(unsafe.Pointer(in.EdgeClusterID))
+	out.StandbyEdgeCluster = (*string)(unsafe.Pointer(in.StandbyEdgeCluster))
+	out.SwitchReplicationMode = (*string)(unsafe.Pointer(in.SwitchReplicationMode))
+	out.SwitchingProfiles = *(*[]string)(unsafe.Pointer(&in.SwitchingProfiles))
+	out.MaxWorkerSegmentMTU = (*int)(unsafe.Pointer(in.MaxWorkerSegmentMTU))
 	out.SNATIPPool = in.SNATIPPool
 	out.Datacenter = (*string)(unsafe.Pointer(in.Datacenter))
 	out.Datastore = (*string)(unsafe.Pointer(in.Datastore))
 	out.DatastoreCluster = (*string)(unsafe.Pointer(in.DatastoreCluster))
+	out.Folder = (*string)(unsafe.Pointer(in.Folder))
 	out.Zones = *(*[]ZoneSpec)(unsafe.Pointer(&in.Zones))
 	out.CaFile = (*string)(unsafe.Pointer(in.CaFile))
 	out.Thumbprint = (*string)(unsafe.Pointer(in.Thumbprint))
@@ -635,6 +805,7 @@ func autoConvert_v1alpha1_ZoneConfig_To_vsphere_ZoneConfig(in *ZoneConfig, out *
 	out.HostSystem = in.HostSystem
 	out.Datastore = in.Datastore
 	out.DatastoreCluster = in.DatastoreCluster
+	out.Folder = in.Folder
 	return nil
 }
 
@@ -650,6 +821,7 @@ func autoConvert_vsphere_ZoneConfig_To_v1alpha1_ZoneConfig(in *vsphere.ZoneConfi
 	out.HostSystem = in.HostSystem
 	out.Datastore = in.Datastore
 	out.DatastoreCluster = in.DatastoreCluster
+	out.Folder = in.Folder
 	return nil
 }
 
@@ -666,6 +838,8 @@ func autoConvert_v1alpha1_ZoneSpec_To_vsphere_ZoneSpec(in *ZoneSpec, out *vspher
 	out.HostSystem = (*string)(unsafe.Pointer(in.HostSystem))
 	out.Datastore = (*string)(unsafe.Pointer(in.Datastore))
 	out.DatastoreCluster = (*string)(unsafe.Pointer(in.DatastoreCluster))
+	out.Folder = (*string)(unsafe.Pointer(in.Folder))
+	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
 	return nil
 }
 
@@ -682,6 +856,8 @@ func autoConvert_vsphere_ZoneSpec_To_v1alpha1_ZoneSpec(in *vsphere.ZoneSpec, out
 	out.HostSystem = (*string)(unsafe.Pointer(in.HostSystem))
 	out.Datastore = (*string)(unsafe.Pointer(in.Datastore))
 	out.DatastoreCluster = (*string)(unsafe.Pointer(in.DatastoreCluster))
+	out.Folder = (*string)(unsafe.Pointer(in.Folder))
+	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
 	return nil
 }
 
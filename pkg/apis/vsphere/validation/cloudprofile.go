@@ -16,16 +16,61 @@ package validation
 
 import (
 	"fmt"
+	"net"
+	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	apisvsphere "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	apihelper "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/helper"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var validLoadBalancerSizeValues = sets.NewString("SMALL", "MEDIUM", "LARGE")
 
+var validSwitchReplicationModes = sets.NewString("MTEP", "SOURCE")
+
+const (
+	// ResourcePoolPathFormatName is the format of plain resource pool names.
+	ResourcePoolPathFormatName = "Name"
+	// ResourcePoolPathFormatInventoryPath is the format of absolute inventory paths of resource pools,
+	// i.e. /<datacenter>/host/<compute cluster or host>/Resources[/<resource pool>...].
+	ResourcePoolPathFormatInventoryPath = "InventoryPath"
+)
+
+var validResourcePoolPathFormats = sets.NewString(ResourcePoolPathFormatName, ResourcePoolPathFormatInventoryPath)
+
+// MaxNamePrefixLength is the maximum length of the name prefix. It leaves enough room in the NSX-T display names
+// for the shoot namespace and the suffixes appended to it.
+const MaxNamePrefixLength = 64
+
+var namePrefixRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// MaxFullClusterNameLength is the maximum length of the base name of the NSX-T objects of a shoot. It leaves enough
+// room in the NSX-T display names (255 characters) for the role suffixes appended to it.
+const MaxFullClusterNameLength = 240
+
+// fullClusterNameRegex matches the base names allowed for NSX-T objects, which are also used in the Terraform
+// configuration.
+var fullClusterNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// nameTemplateSampleNamespaces are the namespaces the name template is validated with. The longest namespace of
+// a shoot in the seed has 63 characters.
+var nameTemplateSampleNamespaces = []string{"shoot--foo--bar", "shoot--" + strings.Repeat("p", 10) + "--" + strings.Repeat("s", 44)}
+
+// ValidateNamePrefixLength validates that the given name prefix has at least the given minimum length. Short name
+// prefixes increase the risk of collisions with NSX-T objects of other tooling on a shared NSX-T manager.
+func ValidateNamePrefixLength(namePrefix string, minLength int) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(namePrefix) < minLength {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("namePrefix"), namePrefix,
+			fmt.Sprintf("must be at least %d characters long to avoid collisions on a shared NSX-T manager", minLength)))
+	}
+	return allErrs
+}
+
 // ValidateCloudProfileConfig validates a CloudProfileConfig object.
 func ValidateCloudProfileConfig(cloudProfile *apisvsphere.CloudProfileConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -40,8 +85,28 @@ func ValidateCloudProfileConfig(cloudProfile *apisvsphere.CloudProfileConfig) fi
 		}
 	}
 
+	namePrefixPath := field.NewPath("namePrefix")
 	if cloudProfile.NamePrefix == "" {
-		allErrs = append(allErrs, field.Required(field.NewPath("namePrefix"), "must provide name prefix for NSX-T resources"))
+		allErrs = append(allErrs, field.Required(namePrefixPath, "must provide name prefix for NSX-T resources"))
+	} else {
+		if len(cloudProfile.NamePrefix) > MaxNamePrefixLength {
+			allErrs = append(allErrs, field.TooLong(namePrefixPath, cloudProfile.NamePrefix, MaxNamePrefixLength))
+		}
+		if !namePrefixRegex.MatchString(cloudProfile.NamePrefix) {
+			allErrs = append(allErrs, field.Invalid(namePrefixPath, cloudProfile.NamePrefix,
+				"must start with an alphanumeric character and consist of alphanumeric characters, '_' or '-'"))
+		}
+	}
+	resourcePoolPathFormat := ""
+	if format := cloudProfile.ResourcePoolPathFormat; format != nil {
+		if validResourcePoolPathFormats.Has(*format) {
+			resourcePoolPathFormat = *format
+		} else {
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("resourcePoolPathFormat"), *format, validResourcePoolPathFormats.List()))
+		}
+	}
+	if cloudProfile.NameTemplate != nil {
+		allErrs = append(allErrs, validateNameTemplate(cloudProfile, field.NewPath("nameTemplate"))...)
 	}
 	if cloudProfile.DefaultClassStoragePolicyName == "" {
 		allErrs = append(allErrs, field.Required(field.NewPath("defaultClassStoragePolicyName"), "must provide defaultClassStoragePolicyName"))
@@ -75,6 +140,8 @@ func ValidateCloudProfileConfig(cloudProfile *apisvsphere.CloudProfileConfig) fi
 		checkMachineImage(machineImagesPath.Index(i), machineImage)
 	}
 
+	allErrs = append(allErrs, validateDNSServers(cloudProfile.DNSServers, field.NewPath("dnsServers"))...)
+
 	regionsPath := field.NewPath("regions")
 	if len(cloudProfile.Regions) == 0 {
 		allErrs = append(allErrs, field.Required(regionsPath, "must provide at least one region"))
@@ -99,21 +166,46 @@ func ValidateCloudProfileConfig(cloudProfile *apisvsphere.CloudProfileConfig) fi
 		if region.LogicalTier0Router == "" {
 			allErrs = append(allErrs, field.Required(regionPath.Child("logicalTier0Router"), fmt.Sprintf("must provide logical tier 0 router for region %s", region.Name)))
 		}
-		if region.EdgeCluster == "" {
+		if region.EdgeCluster == "" && !isSet(region.EdgeClusterID) {
 			allErrs = append(allErrs, field.Required(regionPath.Child("edgeCluster"), fmt.Sprintf("must provide edge cluster for region %s", region.Name)))
 		}
+		if mode := region.SwitchReplicationMode; mode != nil && !validSwitchReplicationModes.Has(*mode) {
+			allErrs = append(allErrs, field.NotSupported(regionPath.Child("switchReplicationMode"), *mode, validSwitchReplicationModes.List()))
+		}
+		switchingProfiles := sets.NewString()
+		for j, profile := range region.SwitchingProfiles {
+			profilePath := regionPath.Child("switchingProfiles").Index(j)
+			if profile == "" {
+				allErrs = append(allErrs, field.Required(profilePath, fmt.Sprintf("must provide switching profile name for region %s", region.Name)))
+			} else if switchingProfiles.Has(profile) {
+				allErrs = append(allErrs, field.Duplicate(profilePath, profile))
+			}
+			switchingProfiles.Insert(profile)
+		}
+		if mtu := region.MaxWorkerSegmentMTU; mtu != nil && (*mtu < MinWorkerSegmentMTU || *mtu > MaxWorkerSegmentMTU) {
+			allErrs = append(allErrs, field.Invalid(regionPath.Child("maxWorkerSegmentMTU"), *mtu,
+				fmt.Sprintf("must be between %d and %d", MinWorkerSegmentMTU, MaxWorkerSegmentMTU)))
+		}
+		if standby := region.StandbyEdgeCluster; standby != nil && (*standby == "" || *standby == region.EdgeCluster) {
+			allErrs = append(allErrs, field.Invalid(regionPath.Child("standbyEdgeCluster"), *standby, fmt.Sprintf("must be an edge cluster other than the edge cluster of region %s", region.Name)))
+		}
 		if len(region.Zones) == 0 {
 			allErrs = append(allErrs, field.Required(regionPath.Child("zones"), fmt.Sprintf("must provide edge cluster for region %s", region.Name)))
 		}
+		allErrs = append(allErrs, validateDNSServers(region.DNSServers, regionPath.Child("dnsServers"))...)
 		if len(cloudProfile.DNSServers) == 0 && len(region.DNSServers) == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("dnsServers"), "must provide dnsServers globally or for each region"))
 			allErrs = append(allErrs, field.Required(regionPath.Child("dnsServers"), fmt.Sprintf("must provide dnsServers globally or for region %s", region.Name)))
 		}
+		zoneNames := sets.NewString()
 		for j, zone := range region.Zones {
 			zonePath := regionPath.Child("zones").Index(j)
 			if zone.Name == "" {
 				allErrs = append(allErrs, field.Required(zonePath.Child("name"), fmt.Sprintf("must provide zone name in zones for region %s", region.Name)))
+			} else if zoneNames.Has(zone.Name) {
+				allErrs = append(allErrs, field.Duplicate(zonePath.Child("name"), zone.Name))
 			}
+			zoneNames.Insert(zone.Name)
 			if !isSet(zone.Datacenter) && !isSet(region.Datacenter) {
 				allErrs = append(allErrs, field.Required(zonePath.Child("datacenter"), fmt.Sprintf("must provide data center either for region %s or its zone %s", region.Name, zone.Name)))
 			}
@@ -123,6 +215,14 @@ func ValidateCloudProfileConfig(cloudProfile *apisvsphere.CloudProfileConfig) fi
 			if !isSet(zone.ComputeCluster) && !isSet(zone.ResourcePool) && !isSet(zone.HostSystem) {
 				allErrs = append(allErrs, field.Required(zonePath.Child("resourcePool"), fmt.Sprintf("must provide either compute cluster, resource pool, or hostsystem for region %s, zone %s", region.Name, zone.Name)))
 			}
+			if isSet(zone.ResourcePool) {
+				datacenter := region.Datacenter
+				if isSet(zone.Datacenter) {
+					datacenter = zone.Datacenter
+				}
+				allErrs = append(allErrs, validateResourcePoolPath(*zone.ResourcePool, datacenter, resourcePoolPathFormat, zonePath.Child("resourcePool"))...)
+			}
+			allErrs = append(allErrs, validateDNSServers(zone.DNSServers, zonePath.Child("dnsServers"))...)
 		}
 		for i, machineImage := range region.MachineImages {
 			checkMachineImage(regionPath.Child("machineImages").Index(i), machineImage)
@@ -132,6 +232,96 @@ func ValidateCloudProfileConfig(cloudProfile *apisvsphere.CloudProfileConfig) fi
 	return allErrs
 }
 
+// validateDNSServers validates that the DNS servers are IP addresses. As the DHCP server of the worker network only
+// supports IPv4, at least one of them must be an IPv4 address.
+func validateDNSServers(dnsServers []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	hasIPv4 := false
+	for i, server := range dnsServers {
+		ip := net.ParseIP(server)
+		if ip == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), server, "must be a valid IP address"))
+			continue
+		}
+		hasIPv4 = hasIPv4 || ip.To4() != nil
+	}
+	if len(allErrs) == 0 && len(dnsServers) > 0 && !hasIPv4 {
+		allErrs = append(allErrs, field.Invalid(fldPath, dnsServers, "must contain an IPv4 address for the IPv4 DHCP server of the worker network"))
+	}
+
+	return allErrs
+}
+
+// validateNameTemplate validates that the name template of the cloud profile renders valid and unique NSX-T names
+// for sample namespaces.
+func validateNameTemplate(cloudProfile *apisvsphere.CloudProfileConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for _, namespace := range nameTemplateSampleNamespaces {
+		name, err := apihelper.FullClusterName(cloudProfile, namespace)
+		if err != nil {
+			return append(allErrs, field.Invalid(fldPath, *cloudProfile.NameTemplate, err.Error()))
+		}
+		if len(name) > MaxFullClusterNameLength {
+			return append(allErrs, field.Invalid(fldPath, *cloudProfile.NameTemplate,
+				fmt.Sprintf("must render names of at most %d characters, but rendered %q", MaxFullClusterNameLength, name)))
+		}
+		if !fullClusterNameRegex.MatchString(name) {
+			return append(allErrs, field.Invalid(fldPath, *cloudProfile.NameTemplate,
+				fmt.Sprintf("must render names starting with an alphanumeric character and consisting of alphanumeric characters, '_', '.' or '-', but rendered %q", name)))
+		}
+		names.Insert(name)
+	}
+	if names.Len() < len(nameTemplateSampleNamespaces) {
+		allErrs = append(allErrs, field.Invalid(fldPath, *cloudProfile.NameTemplate, "must render unique names per shoot, e.g. by using .Namespace"))
+	}
+	return allErrs
+}
+
+// validateResourcePoolPath validates that an absolute inventory path of the resource pool is a resource pool of the
+// given datacenter, and that the resource pool has the given format if set. Without a format, relative paths are
+// accepted as before.
+func validateResourcePoolPath(resourcePool string, datacenter *string, format string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if strings.TrimSpace(resourcePool) != resourcePool {
+		return append(allErrs, field.Invalid(fldPath, resourcePool, "must not have leading or trailing whitespace"))
+	}
+
+	if !strings.HasPrefix(resourcePool, "/") {
+		switch {
+		case format == ResourcePoolPathFormatInventoryPath:
+			allErrs = append(allErrs, field.Invalid(fldPath, resourcePool,
+				"must be an absolute inventory path /<datacenter>/host/<compute cluster or host>/Resources[/<resource pool>...]"))
+		case format == ResourcePoolPathFormatName && strings.Contains(resourcePool, "/"):
+			allErrs = append(allErrs, field.Invalid(fldPath, resourcePool, "must be a plain resource pool name"))
+		}
+		return allErrs
+	}
+
+	if format == ResourcePoolPathFormatName {
+		return append(allErrs, field.Invalid(fldPath, resourcePool, "must be a plain resource pool name"))
+	}
+	segments := strings.Split(strings.TrimPrefix(resourcePool, "/"), "/")
+	for _, segment := range segments {
+		if segment == "" {
+			return append(allErrs, field.Invalid(fldPath, resourcePool, "must not contain empty path segments"))
+		}
+	}
+	if datacenter != nil && !strings.HasPrefix(resourcePool, "/"+strings.Trim(*datacenter, "/")+"/host/") {
+		return append(allErrs, field.Invalid(fldPath, resourcePool, fmt.Sprintf("must be an inventory path within /%s/host of datacenter %s", strings.Trim(*datacenter, "/"), *datacenter)))
+	}
+	for i, segment := range segments {
+		if segment == "host" && i+2 < len(segments) && segments[i+2] == "Resources" {
+			return allErrs
+		}
+	}
+	return append(allErrs, field.Invalid(fldPath, resourcePool,
+		"must be an absolute inventory path /<datacenter>/host/<compute cluster or host>/Resources[/<resource pool>...]"))
+}
+
 func isSet(s *string) bool {
 	return s != nil && *s != ""
 }
@@ -15,7 +15,11 @@
 package validation_test
 
 import (
+	"fmt"
+	"strings"
+
 	apisvsphere "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	apihelper "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/helper"
 	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/validation"
 
 	. "github.com/onsi/ginkgo"
@@ -86,6 +90,90 @@ var _ = Describe("ValidateCloudProfileConfig", func() {
 			}
 		})
 
+		Context("name prefix validation", func() {
+			It("should accept valid name prefixes", func() {
+				for _, prefix := range []string{"prefix", "my_gardener", "gardener-dev2"} {
+					cloudProfileConfig.NamePrefix = prefix
+
+					Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+				}
+			})
+
+			It("should require a name prefix", func() {
+				cloudProfileConfig.NamePrefix = ""
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("namePrefix"),
+				}))))
+			})
+
+			It("should forbid name prefixes with illegal characters", func() {
+				for _, prefix := range []string{"my gardener", "_gardener", "gardener/dev", `gardener"`, "garden${var}"} {
+					cloudProfileConfig.NamePrefix = prefix
+
+					errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("namePrefix"),
+					}))), prefix)
+				}
+			})
+
+			It("should validate the minimum length of name prefixes", func() {
+				Expect(ValidateNamePrefixLength("gardener-dev", 5)).To(BeEmpty())
+				Expect(ValidateNamePrefixLength("gd", 0)).To(BeEmpty())
+
+				for _, prefix := range []string{"", "gd"} {
+					errorList := ValidateNamePrefixLength(prefix, 5)
+
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("namePrefix"),
+						"Detail": Equal("must be at least 5 characters long to avoid collisions on a shared NSX-T manager"),
+					}))), prefix)
+				}
+			})
+
+			It("should allow valid name templates", func() {
+				for _, nameTemplate := range []string{apihelper.DefaultNameTemplate, "k8s.{{ .Namespace }}", "{{ .Namespace }}-{{ .NamePrefix }}"} {
+					nameTemplate := nameTemplate
+					cloudProfileConfig.NameTemplate = &nameTemplate
+
+					Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty(), nameTemplate)
+				}
+			})
+
+			It("should forbid invalid name templates", func() {
+				for _, nameTemplate := range []string{"{{ .Namespace", "{{ .Shoot }}", "{{ .NamePrefix }}", "{{ .Namespace }} {{ .NamePrefix }}", "_{{ .Namespace }}",
+					"{{ .Namespace }}" + strings.Repeat("x", MaxFullClusterNameLength)} {
+					nameTemplate := nameTemplate
+					cloudProfileConfig.NameTemplate = &nameTemplate
+
+					errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("nameTemplate"),
+					}))), nameTemplate)
+				}
+			})
+
+			It("should forbid too long name prefixes", func() {
+				cloudProfileConfig.NamePrefix = strings.Repeat("a", MaxNamePrefixLength+1)
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeTooLong),
+					"Field": Equal("namePrefix"),
+				}))))
+			})
+		})
+
 		Context("machine image validation", func() {
 			It("should validate valid machine image version configuration", func() {
 				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
@@ -164,6 +252,69 @@ var _ = Describe("ValidateCloudProfileConfig", func() {
 				}))))
 			})
 
+			It("should allow valid resource pool paths", func() {
+				for _, pool := range []string{"mypool", "/dc/host/cc/Resources", "/dc/host/cc/Resources/mypool", "/dc/host/cc/Resources/parent/mypool"} {
+					pool := pool
+					cloudProfileConfig.Regions[0].Zones[0].ResourcePool = &pool
+
+					Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty(), pool)
+				}
+			})
+
+			It("should forbid malformed resource pool paths", func() {
+				for _, pool := range []string{" mypool", "/dc/host//Resources/mypool", "/dc/host/cc/mypool", "/other/host/cc/Resources/mypool", "/dc/vm/cc/Resources"} {
+					pool := pool
+					cloudProfileConfig.Regions[0].Zones[0].ResourcePool = &pool
+
+					errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("regions[0].zones[0].resourcePool"),
+					}))), pool)
+				}
+			})
+
+			It("should allow relative resource pool paths without a configured format", func() {
+				pool := "cc/mypool"
+				cloudProfileConfig.Regions[0].Zones[0].ResourcePool = &pool
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+
+				for _, format := range []string{ResourcePoolPathFormatName, ResourcePoolPathFormatInventoryPath} {
+					format := format
+					cloudProfileConfig.ResourcePoolPathFormat = &format
+
+					errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("regions[0].zones[0].resourcePool"),
+					}))), format)
+				}
+			})
+
+			It("should enforce the configured resource pool path format", func() {
+				inventoryPath := "/dc/host/cc/Resources/mypool"
+				for format, pool := range map[string]*string{ResourcePoolPathFormatName: &inventoryPath, ResourcePoolPathFormatInventoryPath: &mypool} {
+					format := format
+					cloudProfileConfig.ResourcePoolPathFormat = &format
+					cloudProfileConfig.Regions[0].Zones[0].ResourcePool = pool
+
+					errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("regions[0].zones[0].resourcePool"),
+					}))), format)
+				}
+
+				format := "Path"
+				cloudProfileConfig.ResourcePoolPathFormat = &format
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("resourcePoolPathFormat"),
+				}))))
+			})
+
 			It("should have a valid compute cluster/resource pool/host system", func() {
 				cloudProfileConfig.Regions[0].Zones[0].ResourcePool = nil
 
@@ -179,6 +330,19 @@ var _ = Describe("ValidateCloudProfileConfig", func() {
 				}))))
 			})
 
+			It("should forbid duplicate zone names", func() {
+				zone := cloudProfileConfig.Regions[0].Zones[0]
+				cloudProfileConfig.Regions[0].Zones = append(cloudProfileConfig.Regions[0].Zones, zone)
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeDuplicate),
+					"Field":    Equal(fmt.Sprintf("regions[0].zones[%d].name", len(cloudProfileConfig.Regions[0].Zones)-1)),
+					"BadValue": Equal(zone.Name),
+				}))))
+			})
+
 			It("should have a valid datastore", func() {
 				cloudProfileConfig.Regions[0].Zones[0].Datastore = nil
 
@@ -204,6 +368,112 @@ var _ = Describe("ValidateCloudProfileConfig", func() {
 					"Field": Equal("regions[0].zones[0].datacenter"),
 				}))))
 			})
+
+			It("should require the edge cluster name or id", func() {
+				ecID := "ec-1"
+				cloudProfileConfig.Regions[0].EdgeCluster = ""
+				cloudProfileConfig.Regions[0].EdgeClusterID = &ecID
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+				Expect(errorList).To(ConsistOf())
+
+				cloudProfileConfig.Regions[0].EdgeClusterID = nil
+				errorList = ValidateCloudProfileConfig(cloudProfileConfig)
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("regions[0].edgeCluster"),
+				}))))
+			})
+
+			It("should validate the switch replication mode", func() {
+				for _, mode := range []string{"MTEP", "SOURCE"} {
+					mode := mode
+					cloudProfileConfig.Regions[0].SwitchReplicationMode = &mode
+
+					Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+				}
+
+				mode := "HEAD"
+				cloudProfileConfig.Regions[0].SwitchReplicationMode = &mode
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("regions[0].switchReplicationMode"),
+				}))))
+			})
+
+			It("should validate the switching profiles", func() {
+				cloudProfileConfig.Regions[0].SwitchingProfiles = []string{"qos", "mac-discovery"}
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+
+				cloudProfileConfig.Regions[0].SwitchingProfiles = []string{"qos", "", "qos"}
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("regions[0].switchingProfiles[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("regions[0].switchingProfiles[2]"),
+					})),
+				))
+			})
+
+			It("should validate the maximum worker segment MTU", func() {
+				mtu := 8900
+				cloudProfileConfig.Regions[0].MaxWorkerSegmentMTU = &mtu
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+
+				mtu = 9001
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("regions[0].maxWorkerSegmentMTU"),
+				}))))
+			})
+
+			It("should forbid a standby edge cluster equal to the edge cluster", func() {
+				standby := cloudProfileConfig.Regions[0].EdgeCluster
+				cloudProfileConfig.Regions[0].StandbyEdgeCluster = &standby
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("regions[0].standbyEdgeCluster"),
+				}))))
+			})
+
+			It("should forbid invalid DNS servers of zones", func() {
+				cloudProfileConfig.Regions[0].Zones[0].DNSServers = []string{"10.10.10.13", "foo"}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("regions[0].zones[0].dnsServers[1]"),
+				}))))
+			})
+
+			It("should accept IPv6 DNS servers together with IPv4 DNS servers", func() {
+				cloudProfileConfig.DNSServers = []string{"1.2.3.4", "fd00::1"}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid DNS servers without an IPv4 address", func() {
+				cloudProfileConfig.Regions[0].DNSServers = []string{"fd00::1", "fd00::2"}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("regions[0].dnsServers"),
+				}))))
+			})
 		})
 	})
 })
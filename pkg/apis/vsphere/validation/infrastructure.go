@@ -0,0 +1,266 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"regexp"
+
+	apisvsphere "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// MinWorkerSegmentMTU is the smallest allowed MTU of the worker network segment.
+	MinWorkerSegmentMTU = 1280
+	// MaxWorkerSegmentMTU is the largest allowed MTU of the worker network segment.
+	MaxWorkerSegmentMTU = 9000
+	// MaxNSXTTags is the maximum number of additional NSX-T tags. NSX-T allows 30 tags per object,
+	// four of which are used to identify the objects of a shoot and its landscape.
+	MaxNSXTTags = 26
+	// MaxNSXTTagScopeLength is the maximum length of the scope of an NSX-T tag.
+	MaxNSXTTagScopeLength = 128
+	// MaxNSXTTagLength is the maximum length of the value of an NSX-T tag.
+	MaxNSXTTagLength = 256
+)
+
+// ValidateInfrastructureConfig validates a InfrastructureConfig object.
+func ValidateInfrastructureConfig(infraConfig *apisvsphere.InfrastructureConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if mtu := infraConfig.WorkerSegmentMTU; mtu != nil && (*mtu < MinWorkerSegmentMTU || *mtu > MaxWorkerSegmentMTU) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("workerSegmentMTU"), *mtu,
+			fmt.Sprintf("must be between %d and %d", MinWorkerSegmentMTU, MaxWorkerSegmentMTU)))
+	}
+
+	if infraConfig.DHCPDomainName != nil {
+		allErrs = append(allErrs, validateDomainName(field.NewPath("dhcpDomainName"), *infraConfig.DHCPDomainName)...)
+	}
+	searchDomains := sets.NewString()
+	for i, domain := range infraConfig.DHCPSearchDomains {
+		idxPath := field.NewPath("dhcpSearchDomains").Index(i)
+		allErrs = append(allErrs, validateDomainName(idxPath, domain)...)
+		if searchDomains.Has(domain) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, domain))
+		}
+		searchDomains.Insert(domain)
+	}
+
+	for i, route := range infraConfig.DHCPStaticRoutes {
+		idxPath := field.NewPath("dhcpStaticRoutes").Index(i)
+		if ip, _, err := net.ParseCIDR(route.Destination); err != nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("destination"), route.Destination, "must be an IPv4 network in CIDR notation"))
+		}
+		if ip := net.ParseIP(route.NextHop); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("nextHop"), route.NextHop, "must be an IPv4 address"))
+		}
+	}
+
+	allErrs = append(allErrs, validateDHCPOptions(field.NewPath("dhcpOptions"), infraConfig.DHCPOptions)...)
+
+	if ip := infraConfig.DHCPServerIP; ip != nil && net.ParseIP(*ip).To4() == nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("dhcpServerIP"), *ip, "must be an IPv4 address"))
+	}
+	if ip := infraConfig.DHCPClientGateway; ip != nil && net.ParseIP(*ip).To4() == nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("dhcpClientGateway"), *ip, "must be an IPv4 address"))
+	}
+
+	validRanges := map[int]apisvsphere.AddressRange{}
+	for i, r := range infraConfig.DHCPRanges {
+		idxPath := field.NewPath("dhcpRanges").Index(i)
+		if errs := validateAddressRange(idxPath, r); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if other, ok := validRanges[j]; ok && addressRangesOverlap(r, other) {
+				allErrs = append(allErrs, field.Invalid(idxPath, fmt.Sprintf("%s-%s", r.Start, r.End),
+					fmt.Sprintf("must not overlap dhcpRanges[%d] %s-%s", j, other.Start, other.End)))
+			}
+		}
+		validRanges[i] = r
+	}
+	for i, r := range infraConfig.DHCPExcludedRanges {
+		allErrs = append(allErrs, validateAddressRange(field.NewPath("dhcpExcludedRanges").Index(i), r)...)
+	}
+
+	allErrs = append(allErrs, validateNSXTTags(field.NewPath("nsxtTags"), infraConfig.NSXTTags)...)
+
+	return allErrs
+}
+
+// immutableInfrastructureConfigFields are the fields of the InfrastructureConfig and the worker network of the shoot
+// (networking.nodes) which must not be changed after creation. Changing the worker network would strand the NSX-T
+// objects of the logical switch and the active leases of the nodes, and changing the address of the DHCP server would
+// strand the leases as well, as their renewals are sent to the server which handed them out.
+var immutableInfrastructureConfigFields = []struct {
+	path  string
+	value func(config *apisvsphere.InfrastructureConfig, workerNetwork string) interface{}
+}{
+	{"networking.nodes", func(_ *apisvsphere.InfrastructureConfig, workerNetwork string) interface{} { return workerNetwork }},
+	{"dhcpServerIP", func(c *apisvsphere.InfrastructureConfig, _ string) interface{} { return c.DHCPServerIP }},
+}
+
+// ImmutableInfrastructureConfigFields returns the paths of the fields of the InfrastructureConfig and of the shoot
+// which must not be changed after creation.
+func ImmutableInfrastructureConfigFields() []string {
+	var paths []string
+	for _, f := range immutableInfrastructureConfigFields {
+		paths = append(paths, f.path)
+	}
+	return paths
+}
+
+// ValidateInfrastructureConfigUpdate validates an update of a InfrastructureConfig object and of the worker network
+// of the shoot.
+func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apisvsphere.InfrastructureConfig, oldWorkerNetwork, newWorkerNetwork string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, f := range immutableInfrastructureConfigFields {
+		oldValue, newValue := f.value(oldConfig, oldWorkerNetwork), f.value(newConfig, newWorkerNetwork)
+		if !equality.Semantic.DeepEqual(oldValue, newValue) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath(f.path), newValue, "field is immutable"))
+		}
+	}
+
+	return allErrs
+}
+
+// managedDHCPOptions are the codes of the DHCP options which are set by the DHCP server itself or by dedicated fields.
+var managedDHCPOptions = map[int]string{
+	1:   "subnet mask",
+	3:   "router",
+	6:   "domain name server",
+	15:  "domain name",
+	26:  "interface MTU",
+	51:  "lease time",
+	119: "domain search list",
+	121: "classless static routes",
+}
+
+// ipv4DHCPOptions are the codes of well-known DHCP options whose values are IPv4 addresses.
+var ipv4DHCPOptions = map[int]string{
+	4:   "time server",
+	42:  "NTP servers",
+	44:  "NetBIOS name servers",
+	150: "TFTP server addresses",
+}
+
+func validateDHCPOptions(fldPath *field.Path, options []apisvsphere.DHCPOption) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	codes := map[int]bool{}
+	for i, option := range options {
+		idxPath := fldPath.Index(i)
+		switch name, managed := managedDHCPOptions[option.Code]; {
+		case option.Code < 1 || option.Code > 254:
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("code"), option.Code, "must be between 1 and 254"))
+		case managed:
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("code"), fmt.Sprintf("option %d (%s) is managed by the extension", option.Code, name)))
+		case codes[option.Code]:
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("code"), option.Code))
+		}
+		codes[option.Code] = true
+
+		if len(option.Values) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("values"), "must provide at least one value"))
+		}
+		for j, value := range option.Values {
+			valuePath := idxPath.Child("values").Index(j)
+			if value == "" {
+				allErrs = append(allErrs, field.Required(valuePath, "must not be empty"))
+			} else if !terraformValueRegex.MatchString(value) {
+				allErrs = append(allErrs, field.Invalid(valuePath, value, `must not contain '"', '\' or '$'`))
+			} else if name, ok := ipv4DHCPOptions[option.Code]; ok && net.ParseIP(value).To4() == nil {
+				allErrs = append(allErrs, field.Invalid(valuePath, value, fmt.Sprintf("must be an IPv4 address for option %d (%s)", option.Code, name)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// reservedNSXTTagScopes are the scopes of the tags identifying the NSX-T objects of a shoot.
+var reservedNSXTTagScopes = sets.NewString("nameprefix", "shoot", "shoot-uid", "garden")
+
+// terraformValueRegex matches the user-provided values which are rendered into the Terraform configuration, like
+// NSX-T tags and the values of DHCP options, i.e. values without quotes, backslashes and interpolations.
+var terraformValueRegex = regexp.MustCompile(`^[^"\\$]*$`)
+
+func validateNSXTTags(fldPath *field.Path, tags []apisvsphere.NSXTTag) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(tags) > MaxNSXTTags {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(tags), MaxNSXTTags))
+	}
+
+	scopes := sets.NewString()
+	for i, tag := range tags {
+		idxPath := fldPath.Index(i)
+		switch {
+		case tag.Scope == "":
+			allErrs = append(allErrs, field.Required(idxPath.Child("scope"), "must provide a scope"))
+		case len(tag.Scope) > MaxNSXTTagScopeLength:
+			allErrs = append(allErrs, field.TooLong(idxPath.Child("scope"), tag.Scope, MaxNSXTTagScopeLength))
+		case !terraformValueRegex.MatchString(tag.Scope):
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("scope"), tag.Scope, `must not contain '"', '\' or '$'`))
+		case reservedNSXTTagScopes.Has(tag.Scope):
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("scope"), fmt.Sprintf("scope %q is reserved for identifying the NSX-T objects of the shoot", tag.Scope)))
+		case scopes.Has(tag.Scope):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("scope"), tag.Scope))
+		}
+		scopes.Insert(tag.Scope)
+
+		if len(tag.Tag) > MaxNSXTTagLength {
+			allErrs = append(allErrs, field.TooLong(idxPath.Child("tag"), tag.Tag, MaxNSXTTagLength))
+		} else if !terraformValueRegex.MatchString(tag.Tag) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("tag"), tag.Tag, `must not contain '"', '\' or '$'`))
+		}
+	}
+	return allErrs
+}
+
+func validateAddressRange(fldPath *field.Path, r apisvsphere.AddressRange) field.ErrorList {
+	allErrs := field.ErrorList{}
+	start, end := net.ParseIP(r.Start).To4(), net.ParseIP(r.End).To4()
+	if start == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("start"), r.Start, "must be an IPv4 address"))
+	}
+	if end == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), r.End, "must be an IPv4 address"))
+	}
+	if start != nil && end != nil && bytes.Compare(start, end) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), r.End, "must not be before the start address"))
+	}
+	return allErrs
+}
+
+// addressRangesOverlap returns whether the given valid address ranges have an address in common.
+func addressRangesOverlap(a, b apisvsphere.AddressRange) bool {
+	return bytes.Compare(net.ParseIP(a.Start).To4(), net.ParseIP(b.End).To4()) <= 0 &&
+		bytes.Compare(net.ParseIP(b.Start).To4(), net.ParseIP(a.End).To4()) <= 0
+}
+
+func validateDomainName(fldPath *field.Path, domain string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Subdomain(domain) {
+		allErrs = append(allErrs, field.Invalid(fldPath, domain, msg))
+	}
+	return allErrs
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"fmt"
+	"strings"
+
+	apisvsphere "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("InfrastructureConfig validation", func() {
+	var infraConfig *apisvsphere.InfrastructureConfig
+
+	BeforeEach(func() {
+		infraConfig = &apisvsphere.InfrastructureConfig{}
+	})
+
+	Describe("#ValidateInfrastructureConfig", func() {
+		It("should return no errors for an empty configuration", func() {
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should allow a valid worker segment MTU", func() {
+			mtu := 8900
+			infraConfig.WorkerSegmentMTU = &mtu
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should forbid a worker segment MTU out of range", func() {
+			for _, value := range []int{0, 1279, 9001} {
+				mtu := value
+				infraConfig.WorkerSegmentMTU = &mtu
+
+				errorList := ValidateInfrastructureConfig(infraConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("workerSegmentMTU"),
+				}))))
+			}
+		})
+
+		It("should allow valid DHCP domain names", func() {
+			domain := "cluster.example.com"
+			infraConfig.DHCPDomainName = &domain
+			infraConfig.DHCPSearchDomains = []string{"example.com", "svc.example.com"}
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should forbid invalid DHCP domain names", func() {
+			domain := "Cluster_Example"
+			infraConfig.DHCPDomainName = &domain
+			infraConfig.DHCPSearchDomains = []string{"example.com", "-invalid", "example.com"}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpDomainName"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpSearchDomains[1]"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("dhcpSearchDomains[2]"),
+			}))))
+		})
+
+		It("should allow valid DHCP static routes", func() {
+			infraConfig.DHCPStaticRoutes = []apisvsphere.DHCPStaticRoute{
+				{Destination: "10.10.0.0/16", NextHop: "10.250.0.254"},
+				{Destination: "0.0.0.0/0", NextHop: "10.250.0.1"},
+			}
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should forbid invalid DHCP static routes", func() {
+			infraConfig.DHCPStaticRoutes = []apisvsphere.DHCPStaticRoute{
+				{Destination: "10.10.0.0", NextHop: "10.250.0.254"},
+				{Destination: "fd00::/64", NextHop: "fd00::1"},
+			}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpStaticRoutes[0].destination"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpStaticRoutes[1].destination"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpStaticRoutes[1].nextHop"),
+			}))))
+		})
+
+		It("should allow valid DHCP options", func() {
+			infraConfig.DHCPOptions = []apisvsphere.DHCPOption{
+				{Code: 43, Values: []string{"01:04:0a:fa:00:05"}},
+				{Code: 42, Values: []string{"10.250.0.5", "10.250.0.6"}},
+				{Code: 66, Values: []string{"pxe.example.com"}},
+			}
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should forbid invalid DHCP options", func() {
+			infraConfig.DHCPOptions = []apisvsphere.DHCPOption{
+				{Code: 255, Values: []string{"foo"}},
+				{Code: 121, Values: []string{"foo"}},
+				{Code: 43, Values: nil},
+				{Code: 43, Values: []string{`"foo"`, ""}},
+				{Code: 150, Values: []string{"tftp.example.com"}},
+			}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpOptions[0].code"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("dhcpOptions[1].code"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("dhcpOptions[2].values"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("dhcpOptions[3].code"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpOptions[3].values[0]"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("dhcpOptions[3].values[1]"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("dhcpOptions[4].values[0]"),
+				"Detail": Equal("must be an IPv4 address for option 150 (TFTP server addresses)"),
+			}))))
+		})
+
+		It("should allow valid DHCP ranges", func() {
+			infraConfig.DHCPRanges = []apisvsphere.AddressRange{{Start: "10.250.0.10", End: "10.250.0.100"}}
+			infraConfig.DHCPExcludedRanges = []apisvsphere.AddressRange{{Start: "10.250.0.50", End: "10.250.0.50"}}
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should forbid invalid DHCP ranges", func() {
+			infraConfig.DHCPRanges = []apisvsphere.AddressRange{{Start: "10.250.0.100", End: "10.250.0.10"}}
+			infraConfig.DHCPExcludedRanges = []apisvsphere.AddressRange{{Start: "10.250.0", End: "fd00::1"}}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpRanges[0].end"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpExcludedRanges[0].start"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpExcludedRanges[0].end"),
+			}))))
+		})
+
+		It("should forbid overlapping DHCP ranges", func() {
+			infraConfig.DHCPRanges = []apisvsphere.AddressRange{
+				{Start: "10.250.0.10", End: "10.250.0.100"},
+				{Start: "10.250.0.101", End: "10.250.0.200"},
+				{Start: "10.250.0.150", End: "10.250.0.250"},
+				{Start: "10.250.0.1", End: "10.250.0.10"},
+			}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("dhcpRanges[2]"),
+				"Detail": Equal("must not overlap dhcpRanges[1] 10.250.0.101-10.250.0.200"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("dhcpRanges[3]"),
+				"Detail": Equal("must not overlap dhcpRanges[0] 10.250.0.10-10.250.0.100"),
+			}))))
+		})
+
+		It("should validate the DHCP server IP", func() {
+			ip := "10.250.0.5"
+			infraConfig.DHCPServerIP = &ip
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+
+			ip = "10.250.0"
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpServerIP"),
+			}))))
+		})
+
+		It("should validate the DHCP client gateway", func() {
+			ip := "10.250.0.6"
+			infraConfig.DHCPClientGateway = &ip
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+
+			ip = "fd00::1"
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("dhcpClientGateway"),
+			}))))
+		})
+
+		It("should allow valid NSX-T tags", func() {
+			infraConfig.NSXTTags = []apisvsphere.NSXTTag{
+				{Scope: "cost-center", Tag: "1234"},
+				{Scope: "classification", Tag: ""},
+			}
+
+			Expect(ValidateInfrastructureConfig(infraConfig)).To(BeEmpty())
+		})
+
+		It("should forbid invalid NSX-T tags", func() {
+			infraConfig.NSXTTags = []apisvsphere.NSXTTag{
+				{Scope: "", Tag: "foo"},
+				{Scope: "shoot", Tag: "foo"},
+				{Scope: "cost-center", Tag: "${var.PASSWORD}"},
+				{Scope: "cost-center", Tag: "1234"},
+				{Scope: strings.Repeat("a", 129), Tag: strings.Repeat("a", 257)},
+			}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("nsxtTags[0].scope"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("nsxtTags[1].scope"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("nsxtTags[2].tag"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("nsxtTags[3].scope"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeTooLong),
+				"Field": Equal("nsxtTags[4].scope"),
+			})), PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeTooLong),
+				"Field": Equal("nsxtTags[4].tag"),
+			}))))
+		})
+
+		It("should forbid too many NSX-T tags", func() {
+			for i := 0; i <= MaxNSXTTags; i++ {
+				infraConfig.NSXTTags = append(infraConfig.NSXTTags, apisvsphere.NSXTTag{Scope: fmt.Sprintf("scope-%d", i)})
+			}
+
+			errorList := ValidateInfrastructureConfig(infraConfig)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeTooMany),
+				"Field": Equal("nsxtTags"),
+			}))))
+		})
+	})
+
+	Describe("#ValidateInfrastructureConfigUpdate", func() {
+		It("should return no errors for an unchanged config", func() {
+			Expect(ValidateInfrastructureConfigUpdate(infraConfig, infraConfig.DeepCopy(), "10.250.0.0/16", "10.250.0.0/16")).To(BeEmpty())
+		})
+
+		It("should allow changes of mutable fields", func() {
+			newConfig := infraConfig.DeepCopy()
+			mtu := 8900
+			domain := "cluster.example.com"
+			newConfig.WorkerSegmentMTU = &mtu
+			newConfig.DHCPDomainName = &domain
+			newConfig.DHCPRanges = []apisvsphere.AddressRange{{Start: "10.250.0.100", End: "10.250.0.200"}}
+
+			Expect(ValidateInfrastructureConfigUpdate(infraConfig, newConfig, "10.250.0.0/16", "10.250.0.0/16")).To(BeEmpty())
+		})
+
+		It("should have an explicit set of immutable fields", func() {
+			Expect(ImmutableInfrastructureConfigFields()).To(ConsistOf("networking.nodes", "dhcpServerIP"))
+		})
+
+		It("should forbid changing the worker network", func() {
+			errorList := ValidateInfrastructureConfigUpdate(infraConfig, infraConfig.DeepCopy(), "10.250.0.0/16", "10.250.0.0/15")
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("networking.nodes"),
+				"BadValue": Equal("10.250.0.0/15"),
+				"Detail":   Equal("field is immutable"),
+			}))))
+		})
+
+		It("should forbid setting, changing and removing the DHCP server IP", func() {
+			ip1, ip2 := "10.250.0.5", "10.250.0.6"
+			configs := []*apisvsphere.InfrastructureConfig{
+				{},
+				{DHCPServerIP: &ip1},
+				{DHCPServerIP: &ip2},
+			}
+
+			for i, oldConfig := range configs {
+				for j, newConfig := range configs {
+					errorList := ValidateInfrastructureConfigUpdate(oldConfig, newConfig, "10.250.0.0/16", "10.250.0.0/16")
+					if i == j {
+						Expect(errorList).To(BeEmpty())
+						continue
+					}
+					Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("dhcpServerIP"),
+						"Detail": Equal("field is immutable"),
+					}))))
+				}
+			}
+		})
+	})
+})
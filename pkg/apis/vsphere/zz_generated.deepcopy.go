@@ -24,6 +24,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressRange) DeepCopyInto(out *AddressRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressRange.
+func (in *AddressRange) DeepCopy() *AddressRange {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPLoadBalancerClass) DeepCopyInto(out *CPLoadBalancerClass) {
 	*out = *in
@@ -72,6 +88,11 @@ func (in *CloudControllerManagerConfig) DeepCopy() *CloudControllerManagerConfig
 func (in *CloudProfileConfig) DeepCopyInto(out *CloudProfileConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.NameTemplate != nil {
+		in, out := &in.NameTemplate, &out.NameTemplate
+		*out = new(string)
+		**out = **in
+	}
 	if in.Regions != nil {
 		in, out := &in.Regions, &out.Regions
 		*out = make([]RegionSpec, len(*in))
@@ -89,6 +110,11 @@ func (in *CloudProfileConfig) DeepCopyInto(out *CloudProfileConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourcePoolPathFormat != nil {
+		in, out := &in.ResourcePoolPathFormat, &out.ResourcePoolPathFormat
+		*out = new(string)
+		**out = **in
+	}
 	if in.MachineImages != nil {
 		in, out := &in.MachineImages, &out.MachineImages
 		*out = make([]MachineImages, len(*in))
@@ -177,6 +203,43 @@ func (in *ControlPlaneConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOption) DeepCopyInto(out *DHCPOption) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOption.
+func (in *DHCPOption) DeepCopy() *DHCPOption {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPStaticRoute) DeepCopyInto(out *DHCPStaticRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPStaticRoute.
+func (in *DHCPStaticRoute) DeepCopy() *DHCPStaticRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPStaticRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailureDomainLabels) DeepCopyInto(out *FailureDomainLabels) {
 	*out = *in
@@ -197,6 +260,58 @@ func (in *FailureDomainLabels) DeepCopy() *FailureDomainLabels {
 func (in *InfrastructureConfig) DeepCopyInto(out *InfrastructureConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.WorkerSegmentMTU != nil {
+		in, out := &in.WorkerSegmentMTU, &out.WorkerSegmentMTU
+		*out = new(int)
+		**out = **in
+	}
+	if in.DHCPDomainName != nil {
+		in, out := &in.DHCPDomainName, &out.DHCPDomainName
+		*out = new(string)
+		**out = **in
+	}
+	if in.DHCPSearchDomains != nil {
+		in, out := &in.DHCPSearchDomains, &out.DHCPSearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DHCPStaticRoutes != nil {
+		in, out := &in.DHCPStaticRoutes, &out.DHCPStaticRoutes
+		*out = make([]DHCPStaticRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = make([]DHCPOption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DHCPRanges != nil {
+		in, out := &in.DHCPRanges, &out.DHCPRanges
+		*out = make([]AddressRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.DHCPExcludedRanges != nil {
+		in, out := &in.DHCPExcludedRanges, &out.DHCPExcludedRanges
+		*out = make([]AddressRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.DHCPServerIP != nil {
+		in, out := &in.DHCPServerIP, &out.DHCPServerIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.DHCPClientGateway != nil {
+		in, out := &in.DHCPClientGateway, &out.DHCPClientGateway
+		*out = new(string)
+		**out = **in
+	}
+	if in.NSXTTags != nil {
+		in, out := &in.NSXTTags, &out.NSXTTags
+		*out = make([]NSXTTag, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -223,6 +338,11 @@ func (in *InfrastructureStatus) DeepCopyInto(out *InfrastructureStatus) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.VsphereConfig.DeepCopyInto(&out.VsphereConfig)
+	if in.DHCPServerIP != nil {
+		in, out := &in.DHCPServerIP, &out.DHCPServerIP
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -334,9 +454,55 @@ func (in *MachineImages) DeepCopy() *MachineImages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSXTTag) DeepCopyInto(out *NSXTTag) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSXTTag.
+func (in *NSXTTag) DeepCopy() *NSXTTag {
+	if in == nil {
+		return nil
+	}
+	out := new(NSXTTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegionSpec) DeepCopyInto(out *RegionSpec) {
 	*out = *in
+	if in.NSXTReadHost != nil {
+		in, out := &in.NSXTReadHost, &out.NSXTReadHost
+		*out = new(string)
+		**out = **in
+	}
+	if in.EdgeClusterID != nil {
+		in, out := &in.EdgeClusterID, &out.EdgeClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.StandbyEdgeCluster != nil {
+		in, out := &in.StandbyEdgeCluster, &out.StandbyEdgeCluster
+		*out = new(string)
+		**out = **in
+	}
+	if in.SwitchReplicationMode != nil {
+		in, out := &in.SwitchReplicationMode, &out.SwitchReplicationMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.SwitchingProfiles != nil {
+		in, out := &in.SwitchingProfiles, &out.SwitchingProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxWorkerSegmentMTU != nil {
+		in, out := &in.MaxWorkerSegmentMTU, &out.MaxWorkerSegmentMTU
+		*out = new(int)
+		**out = **in
+	}
 	if in.Datacenter != nil {
 		in, out := &in.Datacenter, &out.Datacenter
 		*out = new(string)
@@ -352,6 +518,11 @@ func (in *RegionSpec) DeepCopyInto(out *RegionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Folder != nil {
+		in, out := &in.Folder, &out.Folder
+		*out = new(string)
+		**out = **in
+	}
 	if in.Zones != nil {
 		in, out := &in.Zones, &out.Zones
 		*out = make([]ZoneSpec, len(*in))
@@ -496,6 +667,16 @@ func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Folder != nil {
+		in, out := &in.Folder, &out.Folder
+		*out = new(string)
+		**out = **in
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
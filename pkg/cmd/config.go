@@ -88,6 +88,11 @@ func (c *Config) Options() config.ControllerConfiguration {
 	return cfg
 }
 
+// ApplyInfrastructure sets the given infrastructure controller configuration to that of this Config.
+func (c *Config) ApplyInfrastructure(infrastructure *config.InfrastructureControllerConfiguration) {
+	*infrastructure = c.Config.Infrastructure
+}
+
 // ApplyHealthCheckConfig applies the HealthCheckConfig to the config
 func (c *Config) ApplyHealthCheckConfig(config *healthcheckconfig.HealthCheckConfig) {
 	if c.Config.HealthCheckConfig != nil {
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// envNSXTUsername is the environment variable containing the NSX-T user name for the dhcp-leases command.
+	envNSXTUsername = "NSXT_USERNAME"
+	// envNSXTPassword is the environment variable containing the NSX-T password for the dhcp-leases command.
+	envNSXTPassword = "NSXT_PASSWORD"
+)
+
+// NewDHCPLeasesCommand creates a new command printing the active leases of the DHCP server of a shoot's worker network
+// for debugging node IP issues. The NSX-T credentials are read from the environment to keep them out of the process list.
+func NewDHCPLeasesCommand(ctx context.Context) *cobra.Command {
+	var (
+		host        string
+		insecureSSL bool
+		serverID    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dhcp-leases",
+		Short: "Print the active leases of the DHCP server of a worker network",
+		Long: fmt.Sprintf("Print the active leases of the DHCP server of a worker network as JSON. The id of the DHCP server "+
+			"is the dhcp_server_id output variable of the infrastructure's Terraform state. The NSX-T credentials are read "+
+			"from the environment variables %s and %s.", envNSXTUsername, envNSXTPassword),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if host == "" || serverID == "" {
+				return fmt.Errorf("--nsxt-host and --dhcp-server-id are required")
+			}
+
+			client := nsxt.NewClient(host, os.Getenv(envNSXTUsername), os.Getenv(envNSXTPassword), insecureSSL)
+			leases, err := client.GetDHCPLeases(ctx, serverID)
+			if err == nsxt.ErrDHCPLeasesNotAvailable {
+				fmt.Fprintf(cmd.ErrOrStderr(), "The NSX-T manager does not provide the leases of DHCP server %s.\n", serverID)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(leases, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "nsxt-host", "", "the NSX-T manager host")
+	cmd.Flags().BoolVar(&insecureSSL, "nsxt-insecure-ssl", false, "allow insecure HTTPS connections to the NSX-T manager")
+	cmd.Flags().StringVar(&serverID, "dhcp-server-id", "", "the id of the logical DHCP server")
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/infrastructure"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewSupportBundleCommand creates a new command printing a support bundle of the NSX-T objects of a shoot's
+// infrastructure for attaching it to bug reports. The NSX-T credentials are read from the environment like for the
+// dhcp-leases command.
+func NewSupportBundleCommand(ctx context.Context) *cobra.Command {
+	var (
+		host          string
+		insecureSSL   bool
+		statePath     string
+		edgeCluster   string
+		edgeClusterID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Print the NSX-T objects of the infrastructure of a shoot",
+		Long: fmt.Sprintf("Print the NSX-T objects of the infrastructure of a shoot with redacted sensitive fields as JSON. "+
+			"The objects are looked up by the output variables of the infrastructure's Terraform state file. The NSX-T "+
+			"credentials are read from the environment variables %s and %s.", envNSXTUsername, envNSXTPassword),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if host == "" || statePath == "" {
+				return fmt.Errorf("--nsxt-host and --terraform-state are required")
+			}
+			if edgeCluster == "" && edgeClusterID == "" {
+				return fmt.Errorf("--edge-cluster or --edge-cluster-id is required")
+			}
+
+			state, err := ioutil.ReadFile(statePath)
+			if err != nil {
+				return err
+			}
+			outputs, err := infrastructure.ParseTerraformStateOutputs(state)
+			if err != nil {
+				return err
+			}
+
+			client := nsxt.NewClient(host, os.Getenv(envNSXTUsername), os.Getenv(envNSXTPassword), insecureSSL)
+			ref := infrastructure.EdgeClusterRef{ID: edgeClusterID, Name: edgeCluster}
+			data, err := infrastructure.ExportSupportBundle(ctx, client, outputs, ref)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "nsxt-host", "", "the NSX-T manager host")
+	cmd.Flags().BoolVar(&insecureSSL, "nsxt-insecure-ssl", false, "allow insecure HTTPS connections to the NSX-T manager")
+	cmd.Flags().StringVar(&statePath, "terraform-state", "", "the path of the Terraform state file of the infrastructure")
+	cmd.Flags().StringVar(&edgeCluster, "edge-cluster", "", "the name of the edge cluster of the region")
+	cmd.Flags().StringVar(&edgeClusterID, "edge-cluster-id", "", "the id of the edge cluster of the region, takes precedence over --edge-cluster")
+
+	return cmd
+}
@@ -16,30 +16,109 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/config"
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	apihelper "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/helper"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/validation"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/helper"
 	infrainternal "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/infrastructure"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/vsphere"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/common"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/terraformer"
+	"github.com/gardener/gardener-extensions/pkg/util"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 type actuator struct {
 	logger logr.Logger
 	common.ChartRendererContext
+
+	controllerConfig           config.InfrastructureControllerConfiguration
+	gardenID                   string
+	nsxtUserAgent              string
+	nsxtTimeouts               nsxt.Timeouts
+	transportZoneChecker       *infrainternal.TransportZoneChecker
+	dhcpPoolUtilizationTracker *infrainternal.DHCPPoolUtilizationTracker
+	dhcpPoolMetrics            *infrainternal.DHCPPoolMetrics
+	dhcpPoolPollInterval       time.Duration
+	dnsServerProber            *infrainternal.DNSServerProber
+	insecureSSLWarner          *infrainternal.InsecureSSLWarner
+	nsxtOperationLimiter       *infrainternal.ConcurrencyLimiter
+	recorder                   record.EventRecorder
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled Infrastructure resources.
-func NewActuator() infrastructure.Actuator {
-	return &actuator{
-		logger: log.Log.WithName("infrastructure-actuator"),
+func NewActuator(controllerConfig config.InfrastructureControllerConfiguration, gardenID string, recorder record.EventRecorder) infrastructure.Actuator {
+	a := &actuator{
+		logger:               log.Log.WithName("infrastructure-actuator"),
+		controllerConfig:     controllerConfig,
+		gardenID:             gardenID,
+		nsxtUserAgent:        controllerConfig.NSXTUserAgent,
+		transportZoneChecker: infrainternal.NewTransportZoneChecker(),
+		dhcpPoolPollInterval: infrainternal.DefaultDHCPPoolPollInterval,
+		recorder:             recorder,
+	}
+	if a.nsxtUserAgent == "" {
+		a.nsxtUserAgent = nsxt.DefaultUserAgent()
+		if gardenID != "" {
+			a.nsxtUserAgent += fmt.Sprintf(" (garden %s)", gardenID)
+		}
+	}
+	if cfg := controllerConfig.NSXTTimeouts; cfg != nil {
+		if cfg.Dial != nil {
+			a.nsxtTimeouts.Dial = cfg.Dial.Duration
+		}
+		if cfg.TLSHandshake != nil {
+			a.nsxtTimeouts.TLSHandshake = cfg.TLSHandshake.Duration
+		}
+		if cfg.Request != nil {
+			a.nsxtTimeouts.Request = cfg.Request.Duration
+		}
+	}
+	if cfg := controllerConfig.DHCPPoolUtilization; cfg != nil && len(cfg.Levels) > 0 {
+		a.dhcpPoolUtilizationTracker = infrainternal.NewDHCPPoolUtilizationTracker(cfg.Levels, cfg.Hysteresis)
+	}
+	if cfg := controllerConfig.DHCPPoolPollInterval; cfg != nil && cfg.Duration > 0 {
+		a.dhcpPoolPollInterval = cfg.Duration
+	}
+	if controllerConfig.DHCPPoolMetrics {
+		a.dhcpPoolMetrics = infrainternal.NewDHCPPoolMetrics()
+		if err := a.dhcpPoolMetrics.Register(metrics.Registry); err != nil {
+			a.logger.Error(err, "could not register the DHCP IP pool metrics")
+		}
 	}
+	if cfg := controllerConfig.DNSServerProbe; cfg != nil {
+		timeout := infrainternal.DefaultDNSServerProbeTimeout
+		if cfg.Timeout != nil {
+			timeout = cfg.Timeout.Duration
+		}
+		a.dnsServerProber = infrainternal.NewDNSServerProber(timeout)
+	}
+	if controllerConfig.MaxConcurrentNSXTOperations > 0 {
+		a.nsxtOperationLimiter = infrainternal.NewConcurrencyLimiter(controllerConfig.MaxConcurrentNSXTOperations)
+	}
+	if !controllerConfig.SuppressInsecureSSLWarning {
+		a.insecureSSLWarner = infrainternal.NewInsecureSSLWarner(a.logger)
+	}
+	return a
 }
 
 func (a *actuator) Reconcile(ctx context.Context, config *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) error {
@@ -56,6 +135,7 @@ func (a *actuator) updateProviderStatus(
 	ctx context.Context,
 	tf terraformer.Terraformer,
 	infra *extensionsv1alpha1.Infrastructure,
+	config *api.InfrastructureConfig,
 	cluster *extensionscontroller.Cluster,
 ) error {
 	cloudProfileConfig, err := helper.GetCloudProfileConfig(&a.ClientContext, cluster)
@@ -67,9 +147,431 @@ func (a *actuator) updateProviderStatus(
 	if err != nil {
 		return err
 	}
+	a.checkZonePlacement(infra, cluster, status)
+	status.WorkerNetwork = *cluster.Shoot.Spec.Networking.Nodes
+	status.DHCPServerIP = config.DHCPServerIP
 
 	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.Client(), infra, func() error {
 		infra.Status.ProviderStatus = &runtime.RawExtension{Object: status}
 		return nil
 	})
 }
+
+// updateNSXTObjectAnnotations records the ids of the NSX-T objects of the shoot as annotations on the given
+// Infrastructure, if enabled in the controller configuration.
+func (a *actuator) updateNSXTObjectAnnotations(ctx context.Context, tf terraformer.Terraformer, infra *extensionsv1alpha1.Infrastructure) error {
+	if !a.controllerConfig.NSXTObjectAnnotations {
+		return nil
+	}
+
+	ids, err := infrainternal.NSXTObjectIDs(tf)
+	if err != nil {
+		return err
+	}
+	if !infrainternal.SetNSXTObjectAnnotations(infra.DeepCopy(), ids) {
+		return nil
+	}
+	return extensionscontroller.TryUpdate(ctx, retry.DefaultBackoff, a.Client(), infra, func() error {
+		infrainternal.SetNSXTObjectAnnotations(infra, ids)
+		return nil
+	})
+}
+
+// checkImmutableFields checks that the immutable fields of the given InfrastructureConfig and the given worker network
+// were not changed since the last reconciliation recorded in the status of the given Infrastructure. Infrastructures
+// which were reconciled before the worker network was recorded are not checked.
+func (a *actuator) checkImmutableFields(infra *extensionsv1alpha1.Infrastructure, config *api.InfrastructureConfig, workerNetwork string) error {
+	if infra.Status.ProviderStatus == nil || infra.Status.ProviderStatus.Raw == nil {
+		return nil
+	}
+	previous, err := helper.GetInfrastructureStatus(&a.ClientContext, infra.Name, infra.Status.ProviderStatus)
+	if err != nil {
+		return err
+	}
+	if previous.WorkerNetwork == "" {
+		return nil
+	}
+
+	previousConfig := &api.InfrastructureConfig{DHCPServerIP: previous.DHCPServerIP}
+	if errs := validation.ValidateInfrastructureConfigUpdate(previousConfig, config, previous.WorkerNetwork, workerNetwork); len(errs) > 0 {
+		return errors.Wrapf(errs.ToAggregate(), "infrastructure %q cannot be changed", infra.Name)
+	}
+	return nil
+}
+
+// checkZonePlacement emits a warning event on the given Infrastructure if the zone placement of the given status
+// differs from the one of its last status, e.g. because the datastores of a zone changed in the cloud profile.
+// New machines are placed according to the changed placement, existing machines are not moved.
+// Zones which were removed from the cloud profile, but are still used by worker pools, keep their last placement.
+func (a *actuator) checkZonePlacement(infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster, status *api.InfrastructureStatus) {
+	if infra.Status.ProviderStatus == nil || infra.Status.ProviderStatus.Raw == nil {
+		return
+	}
+	previous, err := helper.GetInfrastructureStatus(&a.ClientContext, infra.Name, infra.Status.ProviderStatus)
+	if err != nil {
+		a.logger.Error(err, "could not decode the last infrastructure status", "infrastructure", infra.Name)
+		return
+	}
+
+	var workerZones []string
+	for _, pool := range cluster.Shoot.Spec.Provider.Workers {
+		workerZones = append(workerZones, pool.Zones...)
+	}
+	if retained := infrainternal.RetainRemovedZones(&previous.VsphereConfig, &status.VsphereConfig, workerZones); len(retained) > 0 {
+		a.logger.Info("retained zones removed from cloud profile", "infrastructure", infra.Name, "zones", retained)
+		a.recorder.Eventf(infra, corev1.EventTypeWarning, "ZoneRemoved",
+			"Zones %s were removed from the cloud profile, but are still used by worker pools, their last placement is kept",
+			strings.Join(retained, ", "))
+	}
+
+	if changes := infrainternal.ZonePlacementChanges(&previous.VsphereConfig, &status.VsphereConfig); len(changes) > 0 {
+		a.logger.Info("zone placement changed", "infrastructure", infra.Name, "changes", changes)
+		a.recorder.Eventf(infra, corev1.EventTypeWarning, "ZonePlacementChanged",
+			"Zone placement changed, new machines are placed differently: %s", strings.Join(changes, "; "))
+	}
+}
+
+// preCheck runs the checks against the NSX-T API enabled in the controller configuration.
+func (a *actuator) preCheck(
+	ctx context.Context,
+	creds *internal.Credentials,
+	cloudProfileConfig *api.CloudProfileConfig,
+	regionName string,
+	workerNetwork string,
+) error {
+	if !a.controllerConfig.TransportZonePreCheck && !a.controllerConfig.SNATIPPoolPreCheck && !a.controllerConfig.EdgeClusterPreCheck {
+		return nil
+	}
+
+	client, region, err := a.newNSXTClient(creds, cloudProfileConfig, regionName)
+	if err != nil {
+		return err
+	}
+	if a.controllerConfig.TransportZonePreCheck {
+		if err := a.transportZoneChecker.Check(ctx, client, region.NSXTHost, region.TransportZone); err != nil {
+			return err
+		}
+	}
+	if a.controllerConfig.SNATIPPoolPreCheck {
+		if err := infrainternal.CheckSNATIPPool(ctx, client, region.SNATIPPool, workerNetwork); err != nil {
+			return err
+		}
+		// an overlap is intended if the SNAT IP pool is excluded from the DHCP ranges
+		if !a.controllerConfig.ExcludeSNATIPPoolFromDHCP {
+			snatRanges, err := infrainternal.LookupSNATIPPoolRanges(ctx, client, region.SNATIPPool)
+			if err != nil {
+				return err
+			}
+			if err := infrainternal.CheckWorkerNetwork(workerNetwork, snatRanges); err != nil {
+				return err
+			}
+		}
+	}
+	if a.controllerConfig.EdgeClusterPreCheck {
+		if err := infrainternal.CheckEdgeCluster(ctx, client, infrainternal.NewEdgeClusterRef(region)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// excludedDHCPRanges returns the address ranges which must not be handed out by the DHCP server
+// of the worker network.
+func (a *actuator) excludedDHCPRanges(
+	ctx context.Context,
+	creds *internal.Credentials,
+	cloudProfileConfig *api.CloudProfileConfig,
+	regionName string,
+) ([]infrainternal.IPRange, error) {
+	if !a.controllerConfig.ExcludeSNATIPPoolFromDHCP {
+		return nil, nil
+	}
+
+	client, region, err := a.newNSXTClient(creds, cloudProfileConfig, regionName)
+	if err != nil {
+		return nil, err
+	}
+	return infrainternal.LookupSNATIPPoolRanges(ctx, client, region.SNATIPPool)
+}
+
+// dhcpEdgeCluster returns the standby edge cluster of the region if failover of the DHCP server profile is enabled
+// and the edge cluster of the region cannot host it, and an empty string otherwise.
+func (a *actuator) dhcpEdgeCluster(
+	ctx context.Context,
+	creds *internal.Credentials,
+	cloudProfileConfig *api.CloudProfileConfig,
+	infra *extensionsv1alpha1.Infrastructure,
+) (string, error) {
+	if !a.controllerConfig.DHCPEdgeClusterFailover {
+		return "", nil
+	}
+
+	client, region, err := a.newNSXTClient(creds, cloudProfileConfig, infra.Spec.Region)
+	if err != nil {
+		return "", err
+	}
+	if region.StandbyEdgeCluster == nil || *region.StandbyEdgeCluster == "" {
+		return "", nil
+	}
+
+	primary := infrainternal.NewEdgeClusterRef(region)
+	edgeCluster, err := infrainternal.SelectDHCPEdgeCluster(ctx, client, primary, *region.StandbyEdgeCluster)
+	if err != nil {
+		return "", err
+	}
+	if edgeCluster != "" {
+		a.recorder.Eventf(infra, corev1.EventTypeWarning, "DHCPEdgeClusterFailover",
+			"No member of edge cluster %s is up, the DHCP server is moved to standby edge cluster %s", primary, edgeCluster)
+	}
+	return edgeCluster, nil
+}
+
+// withoutDHCP returns true if the DHCP server of the worker network is to be deleted, i.e. if the shoot is hibernated
+// and the DHCP server is configured to be deleted on hibernation.
+func (a *actuator) withoutDHCP(cluster *extensionscontroller.Cluster) bool {
+	return a.controllerConfig.DeleteDHCPOnHibernation && extensionscontroller.IsHibernated(cluster)
+}
+
+// acquireNSXTOperation waits until the limit of concurrent NSX-T operations of the controller configuration allows
+// another operation. The returned function must be called when the operation has finished.
+func (a *actuator) acquireNSXTOperation(ctx context.Context) (func(), error) {
+	if a.nsxtOperationLimiter == nil {
+		return func() {}, nil
+	}
+	if err := a.nsxtOperationLimiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return a.nsxtOperationLimiter.Release, nil
+}
+
+// warnInsecureSSL logs a warning once per region if the TLS certificate of its NSX-T manager is not verified,
+// unless suppressed in the controller configuration.
+func (a *actuator) warnInsecureSSL(cloudProfileConfig *api.CloudProfileConfig, regionName string) {
+	if a.insecureSSLWarner == nil {
+		return
+	}
+	if region := apihelper.FindRegion(regionName, cloudProfileConfig); region != nil {
+		a.insecureSSLWarner.Warn(region)
+	}
+}
+
+func (a *actuator) newNSXTClient(creds *internal.Credentials, cloudProfileConfig *api.CloudProfileConfig, regionName string) (*nsxt.Client, *api.RegionSpec, error) {
+	region := apihelper.FindRegion(regionName, cloudProfileConfig)
+	if region == nil {
+		return nil, nil, fmt.Errorf("region %q not found in cloud profile", regionName)
+	}
+	client := nsxt.NewClient(region.NSXTHost, creds.NSXTUsername, creds.NSXTPassword, region.NSXTInsecureSSL).
+		WithUserAgent(a.nsxtUserAgent).
+		WithTimeouts(a.nsxtTimeouts)
+	if region.NSXTReadHost != nil && *region.NSXTReadHost != "" {
+		client.WithReadHost(*region.NSXTReadHost)
+	}
+	return client, region, nil
+}
+
+// probeDNSServers emits a warning event on the given Infrastructure if DNS servers handed out by the DHCP server
+// of the worker network are unreachable from the seed. This is best-effort, as the seed may not be able to reach
+// DNS servers which are reachable from the worker network, so it never blocks the reconciliation.
+func (a *actuator) probeDNSServers(
+	ctx context.Context,
+	cloudProfileConfig *api.CloudProfileConfig,
+	infra *extensionsv1alpha1.Infrastructure,
+	cluster *extensionscontroller.Cluster,
+) {
+	if a.dnsServerProber == nil {
+		return
+	}
+
+	region := apihelper.FindRegion(infra.Spec.Region, cloudProfileConfig)
+	if region == nil {
+		return
+	}
+	dnsServers, err := infrainternal.DNSServers(cloudProfileConfig, region, cluster.Shoot)
+	if err != nil {
+		a.logger.Error(err, "could not determine the DNS servers of the worker network", "infrastructure", infra.Name)
+		return
+	}
+
+	if unreachable := a.dnsServerProber.Unreachable(ctx, dnsServers); len(unreachable) > 0 {
+		a.logger.Info("DNS servers of the worker network are unreachable", "infrastructure", infra.Name, "dnsServers", unreachable)
+		a.recorder.Eventf(infra, corev1.EventTypeWarning, "DNSServerUnreachable",
+			"DNS servers %s handed out to the nodes are not reachable from the seed on TCP port 53", strings.Join(unreachable, ", "))
+	}
+}
+
+// checkDHCPLeases checks that the active leases of an existing DHCP server of the worker network are within the DHCP
+// ranges of the given worker network, if enabled in the controller configuration.
+func (a *actuator) checkDHCPLeases(
+	ctx context.Context,
+	tf terraformer.Terraformer,
+	creds *internal.Credentials,
+	cloudProfileConfig *api.CloudProfileConfig,
+	config *api.InfrastructureConfig,
+	infra *extensionsv1alpha1.Infrastructure,
+	workerNetwork string,
+	excludedDHCPRanges []infrainternal.IPRange,
+) error {
+	if !a.controllerConfig.DHCPLeasePreCheck {
+		return nil
+	}
+
+	vars, err := tf.GetStateOutputVariables(infrainternal.TerraformOutputKeyDHCPServerId)
+	if err != nil {
+		// there is no DHCP server yet
+		if !apierrors.IsNotFound(err) && !terraformer.IsVariablesNotFoundError(err) {
+			a.logger.Error(err, "could not read the DHCP server from the terraform state", "infrastructure", infra.Name)
+		}
+		return nil
+	}
+	ranges, err := infrainternal.DHCPAllocationRanges(config, workerNetwork, excludedDHCPRanges)
+	if err != nil {
+		return err
+	}
+	client, _, err := a.newNSXTClient(creds, cloudProfileConfig, infra.Spec.Region)
+	if err != nil {
+		return err
+	}
+	return infrainternal.CheckDHCPLeases(ctx, client, vars[infrainternal.TerraformOutputKeyDHCPServerId], ranges)
+}
+
+// checkPrivateWorkerNetwork checks that the given worker network is within the private networks of RFC 1918, if
+// enabled in the controller configuration. Unless the check is enforced, a public worker network only emits a warning
+// event on the given Infrastructure.
+func (a *actuator) checkPrivateWorkerNetwork(infra *extensionsv1alpha1.Infrastructure, workerNetwork string) error {
+	cfg := a.controllerConfig.PrivateWorkerNetworkCheck
+	if cfg == nil {
+		return nil
+	}
+
+	err := infrainternal.CheckPrivateWorkerNetwork(workerNetwork)
+	if err == nil || cfg.Enforce {
+		return err
+	}
+	a.logger.Info("worker network is not private", "infrastructure", infra.Name, "workerNetwork", workerNetwork)
+	a.recorder.Eventf(infra, corev1.EventTypeWarning, "PublicWorkerNetwork", "%s", err)
+	return nil
+}
+
+// checkDHCPPoolUtilization updates the DHCP IP pool metrics of the given Infrastructure and emits an event on it
+// if the utilization of the DHCP IP pool of the worker network crossed one of the configured levels. Failures
+// are only logged, as the utilization is informational and must not block the reconciliation.
+func (a *actuator) checkDHCPPoolUtilization(
+	ctx context.Context,
+	tf terraformer.Terraformer,
+	creds *internal.Credentials,
+	cloudProfileConfig *api.CloudProfileConfig,
+	infra *extensionsv1alpha1.Infrastructure,
+) {
+	if a.dhcpPoolUtilizationTracker == nil && a.dhcpPoolMetrics == nil {
+		return
+	}
+
+	vars, err := tf.GetStateOutputVariables(infrainternal.TerraformOutputKeyDHCPServerId, infrainternal.TerraformOutputKeyDHCPIPPoolId)
+	if err != nil {
+		a.logger.Error(err, "could not read the DHCP IP pool from the terraform state", "infrastructure", infra.Name)
+		return
+	}
+	client, _, err := a.newNSXTClient(creds, cloudProfileConfig, infra.Spec.Region)
+	if err != nil {
+		a.logger.Error(err, "could not create the NSX-T client", "infrastructure", infra.Name)
+		return
+	}
+
+	serverID, poolID := vars[infrainternal.TerraformOutputKeyDHCPServerId], vars[infrainternal.TerraformOutputKeyDHCPIPPoolId]
+
+	if a.dhcpPoolMetrics != nil {
+		if err := a.dhcpPoolMetrics.Update(ctx, client, infra.Namespace, serverID, poolID); err != nil {
+			a.logger.Error(err, "could not update the DHCP IP pool metrics", "infrastructure", infra.Name)
+		}
+	}
+	if a.dhcpPoolUtilizationTracker == nil {
+		return
+	}
+
+	change, err := a.dhcpPoolUtilizationTracker.Observe(ctx, client, dhcpPoolUtilizationKey(infra), serverID, poolID)
+	if err != nil {
+		a.logger.Error(err, "could not read the DHCP IP pool utilization", "infrastructure", infra.Name)
+		return
+	}
+	if change == nil {
+		return
+	}
+	if change.Rising {
+		a.recorder.Eventf(infra, corev1.EventTypeWarning, "DHCPPoolUtilizationHigh",
+			"Utilization of the DHCP IP pool of the worker network reached %d%% (%d of %d addresses allocated)",
+			change.Level, change.Usage.AllocatedNumber, change.Usage.PoolSize)
+	} else {
+		a.recorder.Eventf(infra, corev1.EventTypeNormal, "DHCPPoolUtilizationNormal",
+			"Utilization of the DHCP IP pool of the worker network dropped below %d%% (%d of %d addresses allocated)",
+			change.Level, change.Usage.AllocatedNumber, change.Usage.PoolSize)
+	}
+}
+
+// forgetDHCPPoolUtilization removes the given Infrastructure from the DHCP IP pool utilization tracker and metrics,
+// e.g. because its DHCP server was deleted.
+func (a *actuator) forgetDHCPPoolUtilization(infra *extensionsv1alpha1.Infrastructure) {
+	if a.dhcpPoolUtilizationTracker != nil {
+		a.dhcpPoolUtilizationTracker.Forget(dhcpPoolUtilizationKey(infra))
+	}
+	if a.dhcpPoolMetrics != nil {
+		a.dhcpPoolMetrics.Forget(infra.Namespace)
+	}
+}
+
+func dhcpPoolUtilizationKey(infra *extensionsv1alpha1.Infrastructure) string {
+	return infra.Namespace + "/" + infra.Name
+}
+
+// pollDHCPPoolUtilization checks the utilization of the DHCP IP pools of all vSphere Infrastructures in the configured
+// interval until the given channel is closed, so that the events and metrics follow the allocation of addresses by
+// new nodes between the reconciliations.
+func (a *actuator) pollDHCPPoolUtilization(stopCh <-chan struct{}) error {
+	ctx := util.ContextFromStopChannel(stopCh)
+	wait.Until(func() {
+		a.checkAllDHCPPoolUtilizations(ctx)
+	}, a.dhcpPoolPollInterval, stopCh)
+	return nil
+}
+
+// checkAllDHCPPoolUtilizations checks the utilization of the DHCP IP pools of all vSphere Infrastructures which
+// have been reconciled at least once and have a DHCP server. Failures are only logged, like in the reconciliation.
+func (a *actuator) checkAllDHCPPoolUtilizations(ctx context.Context) {
+	list := &extensionsv1alpha1.InfrastructureList{}
+	if err := a.Client().List(ctx, list); err != nil {
+		a.logger.Error(err, "could not list the infrastructures for the DHCP IP pool utilization")
+		return
+	}
+
+	for i := range list.Items {
+		infra := &list.Items[i]
+		if infra.Spec.Type != vsphere.Type || infra.DeletionTimestamp != nil || infra.Status.ProviderStatus == nil {
+			continue
+		}
+
+		cluster, err := extensionscontroller.GetCluster(ctx, a.Client(), infra.Namespace)
+		if err != nil {
+			a.logger.Error(err, "could not read the cluster", "infrastructure", infra.Name)
+			continue
+		}
+		if a.withoutDHCP(cluster) {
+			continue
+		}
+		cloudProfileConfig, err := helper.GetCloudProfileConfig(&a.ClientContext, cluster)
+		if err != nil {
+			a.logger.Error(err, "could not read the cloud profile config", "infrastructure", infra.Name)
+			continue
+		}
+		creds, err := infrainternal.GetCredentialsFromInfrastructure(ctx, a.Client(), infra)
+		if err != nil {
+			a.logger.Error(err, "could not read the credentials", "infrastructure", infra.Name)
+			continue
+		}
+		tf, err := internal.NewTerraformer(a.RESTConfig(), creds, vsphere.TerraformerPurposeInfra, infra.Namespace, infra.Name)
+		if err != nil {
+			a.logger.Error(err, "could not create the Terraformer", "infrastructure", infra.Name)
+			continue
+		}
+
+		a.checkDHCPPoolUtilization(ctx, tf, creds, cloudProfileConfig, infra)
+	}
+}
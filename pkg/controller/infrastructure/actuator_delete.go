@@ -17,14 +17,27 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/infrastructure"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/vsphere"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	controllererrors "github.com/gardener/gardener-extensions/pkg/controller/error"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
 
 func (a *actuator) delete(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) error {
+	if gracePeriod := a.controllerConfig.DHCPDeletionGracePeriod; gracePeriod != nil {
+		if remaining := infrastructure.DeletionGracePeriodRemaining(infra.DeletionTimestamp, gracePeriod.Duration, time.Now()); remaining > 0 {
+			a.logger.Info("waiting for grace period before deleting the DHCP server", "infrastructure", infra.Name, "remaining", remaining)
+			return &controllererrors.RequeueAfterError{
+				Cause:        fmt.Errorf("waiting for grace period before deleting the DHCP server, %s remaining", remaining.Round(time.Second)),
+				RequeueAfter: remaining,
+			}
+		}
+	}
+
 	creds, err := infrastructure.GetCredentialsFromInfrastructure(ctx, a.Client(), infra)
 	if err != nil {
 		return err
@@ -35,7 +48,18 @@ func (a *actuator) delete(ctx context.Context, infra *extensionsv1alpha1.Infrast
 		return fmt.Errorf("could not create the Terraformer: %+v", err)
 	}
 
-	return tf.
+	release, err := a.acquireNSXTOperation(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := tf.
 		SetVariablesEnvironment(internal.TerraformerVariablesEnvironmentFromCredentials(creds)).
-		Destroy()
+		Destroy(); err != nil {
+		return err
+	}
+
+	a.forgetDHCPPoolUtilization(infra)
+	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/validation"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/helper"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/infrastructure"
@@ -28,30 +29,67 @@ import (
 	controllererrors "github.com/gardener/gardener-extensions/pkg/controller/error"
 	"github.com/gardener/gardener-extensions/pkg/terraformer"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
 )
 
 func (a *actuator) reconcile(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) error {
+	timer := infrastructure.NewStepTimer()
+
 	config, err := helper.GetInfrastructureConfig(&a.ClientContext, cluster)
 	if err != nil {
 		return err
 	}
+	if err := a.checkImmutableFields(infra, config, *cluster.Shoot.Spec.Networking.Nodes); err != nil {
+		return err
+	}
 
 	cloudProfileConfig, err := helper.GetCloudProfileConfig(&a.ClientContext, cluster)
 	if err != nil {
 		return err
 	}
+	if errs := validation.ValidateNamePrefixLength(cloudProfileConfig.NamePrefix, a.controllerConfig.MinNamePrefixLength); len(errs) > 0 {
+		return errors.Wrapf(errs.ToAggregate(), "name prefix of cloud profile %q is too short", cluster.CloudProfile.Name)
+	}
+
+	a.warnInsecureSSL(cloudProfileConfig, infra.Spec.Region)
 
 	creds, err := infrastructure.GetCredentialsFromInfrastructure(ctx, a.Client(), infra)
 	if err != nil {
 		return err
 	}
 
+	if err := a.checkPrivateWorkerNetwork(infra, *cluster.Shoot.Spec.Networking.Nodes); err != nil {
+		return err
+	}
+	if err := a.preCheck(ctx, creds, cloudProfileConfig, infra.Spec.Region, *cluster.Shoot.Spec.Networking.Nodes); err != nil {
+		return err
+	}
+	a.probeDNSServers(ctx, cloudProfileConfig, infra, cluster)
+
+	excludedDHCPRanges, err := a.excludedDHCPRanges(ctx, creds, cloudProfileConfig, infra.Spec.Region)
+	if err != nil {
+		return err
+	}
+	dhcpEdgeCluster, err := a.dhcpEdgeCluster(ctx, creds, cloudProfileConfig, infra)
+	if err != nil {
+		return err
+	}
+	chartOptions := infrastructure.ChartOptions{
+		ExcludedDHCPRanges:     excludedDHCPRanges,
+		WithoutDHCP:            a.withoutDHCP(cluster),
+		DHCPEdgeCluster:        dhcpEdgeCluster,
+		AdoptDHCPServerRenames: a.controllerConfig.AdoptDHCPServerRenames,
+		GardenID:               a.gardenID,
+	}
+
+	timer.Done("preChecks")
+
 	terraformState, err := terraformer.UnmarshalRawState(infra.Status.State)
 	if err != nil {
 		return err
 	}
 
-	terraformFiles, err := infrastructure.RenderTerraformerChart(a.ChartRenderer(), infra, config, cloudProfileConfig, cluster.Shoot.Spec.Networking)
+	terraformFiles, err := infrastructure.RenderTerraformerChart(a.ChartRenderer(), infra, config, cloudProfileConfig, cluster.Shoot, chartOptions)
 	if err != nil {
 		return err
 	}
@@ -61,6 +99,21 @@ func (a *actuator) reconcile(ctx context.Context, infra *extensionsv1alpha1.Infr
 		return err
 	}
 
+	timer.Done("render")
+
+	if !chartOptions.WithoutDHCP {
+		if err := a.checkDHCPLeases(ctx, tf, creds, cloudProfileConfig, config, infra, *cluster.Shoot.Spec.Networking.Nodes, excludedDHCPRanges); err != nil {
+			return err
+		}
+		timer.Done("dhcpLeaseCheck")
+	}
+
+	release, err := a.acquireNSXTOperation(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if err := tf.
 		InitializeWith(terraformer.DefaultInitializer(a.Client(), terraformFiles.Main, terraformFiles.Variables, terraformFiles.TFVars, terraformState.Data)).
 		Apply(); err != nil {
@@ -72,5 +125,26 @@ func (a *actuator) reconcile(ctx context.Context, infra *extensionsv1alpha1.Infr
 		}
 	}
 
-	return a.updateProviderStatus(ctx, tf, infra, cluster)
+	timer.Done("terraformApply")
+
+	if err := infrastructure.CheckDHCPGateway(tf, config.DHCPClientGateway); err != nil {
+		return err
+	}
+
+	if err := a.updateProviderStatus(ctx, tf, infra, config, cluster); err != nil {
+		return err
+	}
+	if err := a.updateNSXTObjectAnnotations(ctx, tf, infra); err != nil {
+		return err
+	}
+
+	if !chartOptions.WithoutDHCP {
+		a.checkDHCPPoolUtilization(ctx, tf, creds, cloudProfileConfig, infra)
+	} else {
+		a.forgetDHCPPoolUtilization(infra)
+	}
+	timer.Done("status")
+
+	a.logger.Info("reconciled infrastructure", append([]interface{}{"infrastructure", infra.Name}, timer.Summary()...)...)
+	return nil
 }
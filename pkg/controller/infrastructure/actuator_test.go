@@ -0,0 +1,107 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/config"
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/install"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/v1alpha1"
+	infrainternal "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/infrastructure"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Actuator", func() {
+	var (
+		a           *actuator
+		infra       *extensionsv1alpha1.Infrastructure
+		infraConfig *api.InfrastructureConfig
+
+		dhcpServerIP = "10.250.0.5"
+
+		setProviderStatus = func(status *v1alpha1.InfrastructureStatus) {
+			status.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "InfrastructureStatus"}
+			raw, err := json.Marshal(status)
+			Expect(err).NotTo(HaveOccurred())
+			infra.Status.ProviderStatus = &runtime.RawExtension{Raw: raw}
+		}
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		install.Install(scheme)
+
+		a = NewActuator(config.InfrastructureControllerConfiguration{}, "garden", record.NewFakeRecorder(10)).(*actuator)
+		Expect(a.InjectScheme(scheme)).To(Succeed())
+
+		infra = &extensionsv1alpha1.Infrastructure{ObjectMeta: metav1.ObjectMeta{Name: "infra"}}
+		infraConfig = &api.InfrastructureConfig{DHCPServerIP: &dhcpServerIP}
+	})
+
+	Describe("#NewActuator", func() {
+		It("should poll the DHCP IP pools in the default interval", func() {
+			Expect(a.dhcpPoolPollInterval).To(Equal(infrainternal.DefaultDHCPPoolPollInterval))
+		})
+
+		It("should poll the DHCP IP pools in the configured interval", func() {
+			a = NewActuator(config.InfrastructureControllerConfiguration{
+				DHCPPoolPollInterval: &metav1.Duration{Duration: time.Minute},
+			}, "garden", record.NewFakeRecorder(10)).(*actuator)
+
+			Expect(a.dhcpPoolPollInterval).To(Equal(time.Minute))
+		})
+	})
+
+	Describe("#checkImmutableFields", func() {
+		It("should accept a new infrastructure", func() {
+			Expect(a.checkImmutableFields(infra, infraConfig, "10.250.0.0/16")).To(Succeed())
+		})
+
+		It("should accept an infrastructure reconciled before the immutable fields were recorded", func() {
+			setProviderStatus(&v1alpha1.InfrastructureStatus{Network: "network"})
+
+			Expect(a.checkImmutableFields(infra, infraConfig, "10.250.0.0/15")).To(Succeed())
+		})
+
+		It("should accept unchanged immutable fields", func() {
+			setProviderStatus(&v1alpha1.InfrastructureStatus{WorkerNetwork: "10.250.0.0/16", DHCPServerIP: &dhcpServerIP})
+
+			Expect(a.checkImmutableFields(infra, infraConfig, "10.250.0.0/16")).To(Succeed())
+		})
+
+		It("should reject a changed worker network", func() {
+			setProviderStatus(&v1alpha1.InfrastructureStatus{WorkerNetwork: "10.250.0.0/16", DHCPServerIP: &dhcpServerIP})
+
+			err := a.checkImmutableFields(infra, infraConfig, "10.250.0.0/15")
+			Expect(err).To(MatchError(`infrastructure "infra" cannot be changed: networking.nodes: Invalid value: "10.250.0.0/15": field is immutable`))
+		})
+
+		It("should reject a changed DHCP server IP", func() {
+			setProviderStatus(&v1alpha1.InfrastructureStatus{WorkerNetwork: "10.250.0.0/16"})
+
+			err := a.checkImmutableFields(infra, infraConfig, "10.250.0.0/16")
+			Expect(err).To(MatchError(`infrastructure "infra" cannot be changed: dhcpServerIP: Invalid value: "10.250.0.5": field is immutable`))
+		})
+	})
+})
@@ -17,6 +17,7 @@
 package infrastructure
 
 import (
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/vsphere"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -34,17 +35,29 @@ type AddOptions struct {
 	Controller controller.Options
 	// IgnoreOperationAnnotation specifies whether to ignore the operation annotation or not.
 	IgnoreOperationAnnotation bool
+	// Infrastructure is the configuration of the infrastructure controller.
+	Infrastructure config.InfrastructureControllerConfiguration
+	// GardenId is the Gardener garden identity
+	GardenId string
 }
 
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) error {
-	return infrastructure.Add(mgr, infrastructure.AddArgs{
-		Actuator:          NewActuator(),
+	a := NewActuator(opts.Infrastructure, opts.GardenId, mgr.GetEventRecorderFor(infrastructure.ControllerName)).(*actuator)
+	if err := infrastructure.Add(mgr, infrastructure.AddArgs{
+		Actuator:          a,
 		ControllerOptions: opts.Controller,
 		Predicates:        infrastructure.DefaultPredicates(opts.IgnoreOperationAnnotation),
 		Type:              vsphere.Type,
-	})
+	}); err != nil {
+		return err
+	}
+
+	if a.dhcpPoolUtilizationTracker == nil && a.dhcpPoolMetrics == nil {
+		return nil
+	}
+	return mgr.Add(manager.RunnableFunc(a.pollDHCPPoolUtilization))
 }
 
 // AddToManager adds a controller with the default Options.
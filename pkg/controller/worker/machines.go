@@ -26,6 +26,7 @@ import (
 	apisvsphere "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/helper"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal"
+	internalhelper "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/helper"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/vsphere"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
@@ -108,6 +109,10 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 	if len(w.worker.Spec.SSHPublicKey) == 0 {
 		return fmt.Errorf("missing sshPublicKey for infrastructure")
 	}
+	sshPublicKey, err := internalhelper.NormalizeSSHPublicKey(string(w.worker.Spec.SSHPublicKey))
+	if err != nil {
+		return err
+	}
 
 	for _, pool := range w.worker.Spec.Pools {
 		workerPoolHash, err := worker.WorkerPoolHash(pool, w.cluster)
@@ -138,7 +143,7 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 			}
 			machineClassSpec := map[string]interface{}{
 				"region":     infrastructureStatus.VsphereConfig.Region,
-				"sshKeys":    []string{string(w.worker.Spec.SSHPublicKey)},
+				"sshKeys":    []string{sshPublicKey},
 				"datacenter": zoneConfig.Datacenter,
 				"network":    infrastructureStatus.Network,
 				"templateVM": machineImagePath,
@@ -160,7 +165,11 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 					machineClassSpec[key] = value
 				}
 			}
-			addOptional("folder", infrastructureStatus.VsphereConfig.Folder)
+			folder := infrastructureStatus.VsphereConfig.Folder
+			if zoneConfig.Folder != "" {
+				folder = zoneConfig.Folder
+			}
+			addOptional("folder", folder)
 			addOptional("guestId", machineImageGuestID)
 			addOptional("hostSystem", zoneConfig.HostSystem)
 			addOptional("resourcePool", zoneConfig.ResourcePool)
@@ -161,7 +161,7 @@ var _ = Describe("Machines", func() {
 				datastore2 = "my-ds2"
 				folder = "my-folder"
 				networkName = "mynetwork"
-				sshKey = "aaabbbcccddd"
+				sshKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAgQMiJZHeaG7E0B8G/0X51u2A+Bkh1i/k/Mzh8xr7QP"
 				userData = []byte("some-user-data")
 
 				namePool1 = "pool-1"
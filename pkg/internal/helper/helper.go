@@ -18,6 +18,8 @@ package helper
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/validation"
 	"github.com/gardener/gardener-extensions/pkg/controller"
@@ -33,6 +35,7 @@ func GetCloudProfileConfig(ctx *common.ClientContext, cluster *controller.Cluste
 		if _, _, err := ctx.Decoder().Decode(cluster.CloudProfile.Spec.ProviderConfig.Raw, nil, cloudProfileConfig); err != nil {
 			return nil, errors.Wrapf(err, "could not decode providerConfig of cloudProfile for '%s'", cluster.Shoot.Name)
 		}
+		cloudProfileConfig.NamePrefix = strings.TrimSpace(cloudProfileConfig.NamePrefix)
 		// TODO validate cloud profile on admission instead
 		if errs := validation.ValidateCloudProfileConfig(cloudProfileConfig); len(errs) > 0 {
 			return nil, errors.Wrap(errs.ToAggregate(), fmt.Sprintf("validation of providerConfig of cloud profile %q failed", cluster.CloudProfile.Name))
@@ -67,6 +70,9 @@ func GetInfrastructureConfig(ctx *common.ClientContext, cluster *controller.Clus
 		if _, _, err := ctx.Decoder().Decode(source.Raw, nil, config); err != nil {
 			return nil, err
 		}
+		if errs := validation.ValidateInfrastructureConfig(config); len(errs) > 0 {
+			return nil, errors.Wrap(errs.ToAggregate(), fmt.Sprintf("validation of infrastructureConfig of shoot %q failed", cluster.Shoot.Name))
+		}
 		return config, nil
 	}
 	return config, nil
@@ -17,6 +17,8 @@
 package helper
 
 import (
+	"bytes"
+
 	vsphere "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/install"
 	"github.com/gardener/gardener-extensions/pkg/controller"
@@ -155,5 +157,15 @@ machineImages:
 
 			Expect(result).To(Equal(cloudProfileConfig))
 		})
+
+		It("should trim the name prefix", func() {
+			raw := &cluster.CloudProfile.Spec.ProviderConfig.Raw
+			*raw = bytes.Replace(*raw, []byte("namePrefix: nameprefix"), []byte(`namePrefix: " nameprefix "`), 1)
+
+			result, err := GetCloudProfileConfig(&ctx, cluster)
+			Expect(err).To(BeNil())
+
+			Expect(result.NamePrefix).To(Equal("nameprefix"))
+		})
 	})
 })
@@ -0,0 +1,83 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sshKeyTypes are the supported types of SSH public keys.
+var sshKeyTypes = map[string]bool{
+	"ssh-rsa":             true,
+	"ssh-dss":             true,
+	"ssh-ed25519":         true,
+	"ecdsa-sha2-nistp256": true,
+	"ecdsa-sha2-nistp384": true,
+	"ecdsa-sha2-nistp521": true,
+}
+
+// sshKeyCommentRegex matches the comments which are kept by NormalizeSSHPublicKey.
+var sshKeyCommentRegex = regexp.MustCompile(`^[a-zA-Z0-9@._+:/=-]+$`)
+
+// NormalizeSSHPublicKey normalizes the given SSH public key in authorized_keys format to its type, its base64 encoded
+// key, and its comment. The comment is dropped if it contains characters which could break the Terraform configuration
+// or cloud-init. Keys with options, with multiple lines, or with an invalid key are rejected.
+func NormalizeSSHPublicKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if strings.ContainsAny(key, "\r\n") {
+		return "", fmt.Errorf("SSH public key must be a single line")
+	}
+
+	fields := strings.Fields(key)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("SSH public key is empty")
+	}
+	if !sshKeyTypes[fields[0]] {
+		return "", fmt.Errorf("SSH public key must start with a supported key type, options are not supported")
+	}
+	if len(fields) < 2 {
+		return "", fmt.Errorf("SSH public key of type %s has no key", fields[0])
+	}
+	if err := checkSSHPublicKeyBlob(fields[0], fields[1]); err != nil {
+		return "", err
+	}
+
+	normalized := fields[0] + " " + fields[1]
+	if comment := strings.Join(fields[2:], " "); len(fields) == 3 && sshKeyCommentRegex.MatchString(comment) {
+		normalized += " " + comment
+	}
+	return normalized, nil
+}
+
+// checkSSHPublicKeyBlob checks that the given base64 encoded key is a key of the given type.
+func checkSSHPublicKeyBlob(keyType, encoded string) error {
+	blob, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("SSH public key of type %s is not base64 encoded: %v", keyType, err)
+	}
+	if len(blob) < 4 {
+		return fmt.Errorf("SSH public key of type %s is too short", keyType)
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if uint64(n) > uint64(len(blob)-4) || !bytes.Equal(blob[4:4+n], []byte(keyType)) {
+		return fmt.Errorf("SSH public key is not of type %s", keyType)
+	}
+	return nil
+}
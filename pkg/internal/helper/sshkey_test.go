@@ -0,0 +1,62 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSH public key", func() {
+	const key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAgQMiJZHeaG7E0B8G/0X51u2A+Bkh1i/k/Mzh8xr7QP"
+
+	Describe("#NormalizeSSHPublicKey", func() {
+		It("should keep plain keys", func() {
+			Expect(NormalizeSSHPublicKey(key + "\n")).To(Equal(key))
+		})
+
+		It("should keep simple comments", func() {
+			Expect(NormalizeSSHPublicKey("  " + key + "   user@example.com\n")).To(Equal(key + " user@example.com"))
+		})
+
+		It("should drop comments which could break the configuration", func() {
+			Expect(NormalizeSSHPublicKey(key + ` "${var.PASSWORD}"`)).To(Equal(key))
+			Expect(NormalizeSSHPublicKey(key + " my key")).To(Equal(key))
+		})
+
+		It("should reject keys with options", func() {
+			_, err := NormalizeSSHPublicKey(`no-pty,command="/bin/true" ` + key)
+			Expect(err).To(MatchError("SSH public key must start with a supported key type, options are not supported"))
+		})
+
+		It("should reject multiple lines", func() {
+			_, err := NormalizeSSHPublicKey(key + "\n" + key)
+			Expect(err).To(MatchError("SSH public key must be a single line"))
+		})
+
+		It("should reject keys of another type than stated", func() {
+			_, err := NormalizeSSHPublicKey("ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIAgQMiJZHeaG7E0B8G/0X51u2A+Bkh1i/k/Mzh8xr7QP")
+			Expect(err).To(MatchError("SSH public key is not of type ssh-rsa"))
+		})
+
+		It("should reject invalid keys", func() {
+			_, err := NormalizeSSHPublicKey("ssh-ed25519 aaabbbcccddd!")
+			Expect(err).To(HaveOccurred())
+
+			_, err = NormalizeSSHPublicKey("ssh-ed25519")
+			Expect(err).To(MatchError("SSH public key of type ssh-ed25519 has no key"))
+		})
+	})
+})
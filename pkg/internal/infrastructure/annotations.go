@@ -0,0 +1,73 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/terraformer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nsxtObjectAnnotations maps the Terraform outputs with the ids of the NSX-T objects of a shoot to the annotations
+// of its Infrastructure resource.
+var nsxtObjectAnnotations = map[string]string{
+	TerraformOutputKeyLogicalRouterId: "vsphere.provider.extensions.gardener.cloud/nsxt-logical-router-id",
+	TerraformOutputKeyLogicalSwitchId: "vsphere.provider.extensions.gardener.cloud/nsxt-logical-switch-id",
+	TerraformOutputKeyDHCPProfileId:   "vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-profile-id",
+	TerraformOutputKeyDHCPServerId:    "vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-server-id",
+	TerraformOutputKeyDHCPPortId:      "vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-port-id",
+	TerraformOutputKeyDHCPIPPoolId:    "vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-ip-pool-id",
+}
+
+// NSXTObjectIDs returns the ids of the NSX-T objects of a shoot from the Terraform state by output key.
+// The ids of the DHCP objects are omitted if the worker network has no DHCP server.
+func NSXTObjectIDs(tf terraformer.Terraformer) (map[string]string, error) {
+	ids, err := tf.GetStateOutputVariables(TerraformOutputKeyLogicalRouterId, TerraformOutputKeyLogicalSwitchId)
+	if err != nil {
+		return nil, err
+	}
+	dhcpIDs, err := tf.GetStateOutputVariables(TerraformOutputKeyDHCPProfileId, TerraformOutputKeyDHCPServerId,
+		TerraformOutputKeyDHCPPortId, TerraformOutputKeyDHCPIPPoolId)
+	if err != nil && !terraformer.IsVariablesNotFoundError(err) {
+		return nil, err
+	}
+	for key, id := range dhcpIDs {
+		ids[key] = id
+	}
+	return ids, nil
+}
+
+// SetNSXTObjectAnnotations sets the annotations with the given ids of NSX-T objects on the given object, and removes
+// the annotations of NSX-T objects without id. It returns whether the annotations were changed.
+func SetNSXTObjectAnnotations(obj metav1.Object, ids map[string]string) bool {
+	annotations := obj.GetAnnotations()
+	changed := false
+	for key, annotation := range nsxtObjectAnnotations {
+		id := ids[key]
+		if current, ok := annotations[annotation]; id == "" {
+			if ok {
+				delete(annotations, annotation)
+				changed = true
+			}
+		} else if current != id || !ok {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[annotation] = id
+			changed = true
+		}
+	}
+	obj.SetAnnotations(annotations)
+	return changed
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Annotations", func() {
+	var outputs map[string]string
+
+	BeforeEach(func() {
+		outputs = map[string]string{
+			TerraformOutputKeyLogicalRouterId: "router-id",
+			TerraformOutputKeyLogicalSwitchId: "switch-id",
+			TerraformOutputKeyDHCPProfileId:   "profile-id",
+			TerraformOutputKeyDHCPServerId:    "server-id",
+			TerraformOutputKeyDHCPPortId:      "port-id",
+			TerraformOutputKeyDHCPIPPoolId:    "pool-id",
+		}
+	})
+
+	Describe("#NSXTObjectIDs", func() {
+		It("should return the ids of all NSX-T objects", func() {
+			Expect(NSXTObjectIDs(&fakeTerraformer{outputs: outputs})).To(Equal(outputs))
+		})
+	})
+
+	Describe("#SetNSXTObjectAnnotations", func() {
+		var infra *extensionsv1alpha1.Infrastructure
+
+		BeforeEach(func() {
+			infra = &extensionsv1alpha1.Infrastructure{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"foo": "bar"}},
+			}
+		})
+
+		It("should write the annotations", func() {
+			Expect(SetNSXTObjectAnnotations(infra, outputs)).To(BeTrue())
+
+			Expect(infra.Annotations).To(Equal(map[string]string{
+				"foo": "bar",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-logical-router-id": "router-id",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-logical-switch-id": "switch-id",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-profile-id":   "profile-id",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-server-id":    "server-id",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-port-id":      "port-id",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-dhcp-ip-pool-id":   "pool-id",
+			}))
+			Expect(SetNSXTObjectAnnotations(infra, outputs)).To(BeFalse())
+		})
+
+		It("should update the annotations", func() {
+			SetNSXTObjectAnnotations(infra, outputs)
+
+			Expect(SetNSXTObjectAnnotations(infra, map[string]string{
+				TerraformOutputKeyLogicalRouterId: "router-id",
+				TerraformOutputKeyLogicalSwitchId: "new-switch-id",
+			})).To(BeTrue())
+
+			Expect(infra.Annotations).To(Equal(map[string]string{
+				"foo": "bar",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-logical-router-id": "router-id",
+				"vsphere.provider.extensions.gardener.cloud/nsxt-logical-switch-id": "new-switch-id",
+			}))
+		})
+
+		It("should handle objects without annotations", func() {
+			infra.Annotations = nil
+
+			Expect(SetNSXTObjectAnnotations(infra, nil)).To(BeFalse())
+			Expect(SetNSXTObjectAnnotations(infra, outputs)).To(BeTrue())
+			Expect(infra.Annotations).To(HaveLen(6))
+		})
+	})
+})
@@ -0,0 +1,207 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	"github.com/pkg/errors"
+)
+
+// CheckSNATIPPool checks that the SNAT IP pool with the given name has free addresses left and a subnet of the
+// address family of the given worker network, as Terraform would otherwise only fail late on allocating the SNAT
+// IP address or on creating the SNAT rule.
+func CheckSNATIPPool(ctx context.Context, client *nsxt.Client, poolName, workerNetwork string) error {
+	pool, err := client.FindIPPoolByName(ctx, poolName)
+	if err != nil {
+		return errors.Wrapf(err, "could not read SNAT IP pool %q", poolName)
+	}
+	if pool.PoolUsage.FreeIDs <= 0 {
+		return fmt.Errorf("SNAT IP pool %q has no free addresses (%d of %d allocated)",
+			poolName, pool.PoolUsage.AllocatedIDs, pool.PoolUsage.TotalIDs)
+	}
+
+	_, workers, err := net.ParseCIDR(workerNetwork)
+	if err != nil {
+		return err
+	}
+	// the subnets are not returned by all NSX-T versions
+	if len(pool.Subnets) == 0 {
+		return nil
+	}
+	for _, subnet := range pool.Subnets {
+		if _, network, err := net.ParseCIDR(subnet.CIDR); err == nil && isIPv4(network.IP) == isIPv4(workers.IP) {
+			return nil
+		}
+	}
+	return fmt.Errorf("SNAT IP pool %q has no %s subnet for the worker network %s", poolName, addressFamily(workers.IP), workerNetwork)
+}
+
+func isIPv4(ip net.IP) bool {
+	return ip.To4() != nil
+}
+
+func addressFamily(ip net.IP) string {
+	if isIPv4(ip) {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// EdgeClusterRef references an edge cluster by id, or by display name if the id is not known.
+type EdgeClusterRef struct {
+	// ID is the id of the edge cluster.
+	ID string
+	// Name is the display name of the edge cluster.
+	Name string
+}
+
+func (r EdgeClusterRef) String() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return r.Name
+}
+
+// NewEdgeClusterRef returns the reference of the edge cluster of the given region.
+func NewEdgeClusterRef(region *api.RegionSpec) EdgeClusterRef {
+	ref := EdgeClusterRef{Name: region.EdgeCluster}
+	if region.EdgeClusterID != nil {
+		ref.ID = *region.EdgeClusterID
+	}
+	return ref
+}
+
+// lookupEdgeCluster reads the referenced edge cluster by its id if known, and by its name otherwise.
+func lookupEdgeCluster(ctx context.Context, client *nsxt.Client, ref EdgeClusterRef) (*nsxt.EdgeCluster, error) {
+	if ref.ID != "" {
+		return client.GetEdgeCluster(ctx, ref.ID)
+	}
+	return client.FindEdgeClusterByName(ctx, ref.Name)
+}
+
+// EdgeClusterUnavailableError is returned if an edge cluster cannot host the DHCP server of the worker network,
+// because it has no members or none of them is up.
+type EdgeClusterUnavailableError struct {
+	// Name is the name of the edge cluster.
+	Name string
+	// Status is the status of the edge cluster, or empty if it has no members.
+	Status string
+}
+
+func (e *EdgeClusterUnavailableError) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("edge cluster %q has no members to host the DHCP server", e.Name)
+	}
+	return fmt.Sprintf("edge cluster %q has no member which is up to host the DHCP server (status %s)", e.Name, e.Status)
+}
+
+// IsEdgeClusterUnavailable returns true if the given error is an EdgeClusterUnavailableError.
+func IsEdgeClusterUnavailable(err error) bool {
+	_, ok := err.(*EdgeClusterUnavailableError)
+	return ok
+}
+
+// CheckEdgeCluster checks that the referenced edge cluster can host the DHCP server of the worker network,
+// i.e. that it has members and at least one of them is up. Otherwise, creating the DHCP server profile succeeds,
+// but Terraform only fails late on realizing the DHCP server and port.
+func CheckEdgeCluster(ctx context.Context, client *nsxt.Client, ref EdgeClusterRef) error {
+	name := ref.String()
+	cluster, err := lookupEdgeCluster(ctx, client, ref)
+	if err != nil {
+		return errors.Wrapf(err, "could not read edge cluster %q", name)
+	}
+	if len(cluster.Members) == 0 {
+		return &EdgeClusterUnavailableError{Name: name}
+	}
+
+	status, err := client.GetEdgeClusterStatus(ctx, cluster.ID)
+	if err != nil {
+		return errors.Wrapf(err, "could not read status of edge cluster %q", name)
+	}
+	for _, member := range status.MemberStatus {
+		if member.Status == nsxt.EdgeClusterMemberStatusUp {
+			return nil
+		}
+	}
+	return &EdgeClusterUnavailableError{Name: name, Status: status.EdgeClusterStatus}
+}
+
+// SelectDHCPEdgeCluster returns the name of the given standby edge cluster if the given primary edge cluster cannot
+// host the DHCP server profile, and an empty string if the primary edge cluster can host it. Other errors, e.g. of
+// the NSX-T API, are returned as they are, so that the DHCP server is not moved to the standby edge cluster by mistake.
+func SelectDHCPEdgeCluster(ctx context.Context, client *nsxt.Client, primary EdgeClusterRef, standby string) (string, error) {
+	primaryErr := CheckEdgeCluster(ctx, client, primary)
+	if primaryErr == nil {
+		return "", nil
+	}
+	if !IsEdgeClusterUnavailable(primaryErr) {
+		return "", primaryErr
+	}
+	if err := CheckEdgeCluster(ctx, client, EdgeClusterRef{Name: standby}); err != nil {
+		return "", errors.Wrapf(err, "could not fail over to standby edge cluster (%s)", primaryErr)
+	}
+	return standby, nil
+}
+
+// TransportZoneChecker checks that transport zones are of overlay type, as the DHCP server cannot be
+// attached to a logical switch on a VLAN backed transport zone. The transport type is cached per
+// NSX-T host and transport zone, as it cannot be changed after creation.
+type TransportZoneChecker struct {
+	lock           sync.Mutex
+	transportTypes map[string]string
+}
+
+// NewTransportZoneChecker creates a new TransportZoneChecker.
+func NewTransportZoneChecker() *TransportZoneChecker {
+	return &TransportZoneChecker{
+		transportTypes: map[string]string{},
+	}
+}
+
+// Check checks that the transport zone with the given name on the given NSX-T host is of overlay type.
+func (c *TransportZoneChecker) Check(ctx context.Context, client *nsxt.Client, host, name string) error {
+	transportType, err := c.transportType(ctx, client, host, name)
+	if err != nil {
+		return err
+	}
+	if transportType != nsxt.TransportTypeOverlay {
+		return fmt.Errorf("transport zone %q must be of type %s, but is of type %s", name, nsxt.TransportTypeOverlay, transportType)
+	}
+	return nil
+}
+
+func (c *TransportZoneChecker) transportType(ctx context.Context, client *nsxt.Client, host, name string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := host + "/" + name
+	if transportType, ok := c.transportTypes[key]; ok {
+		return transportType, nil
+	}
+
+	zone, err := client.FindTransportZoneByName(ctx, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read transport zone %q", name)
+	}
+	c.transportTypes[key] = zone.TransportType
+	return zone.TransportType, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checks", func() {
+	var (
+		ctx       = context.TODO()
+		server    *httptest.Server
+		client    *nsxt.Client
+		responses map[string]string
+		requests  int
+	)
+
+	BeforeEach(func() {
+		responses = map[string]string{}
+		requests = 0
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			response, ok := responses[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		}))
+		client = nsxt.NewClient(server.URL, "user", "password", true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#CheckSNATIPPool", func() {
+		It("should succeed if the pool has free addresses", func() {
+			responses["/api/v1/pools/ip-pools"] = `{"results": [
+				{"id": "1", "display_name": "other", "pool_usage": {"total_ids": 10, "allocated_ids": 10, "free_ids": 0}},
+				{"id": "2", "display_name": "snat", "pool_usage": {"total_ids": 10, "allocated_ids": 9, "free_ids": 1}}
+			]}`
+
+			Expect(CheckSNATIPPool(ctx, client, "snat", "10.250.0.0/16")).To(Succeed())
+		})
+
+		It("should fail if the pool is exhausted", func() {
+			responses["/api/v1/pools/ip-pools"] = `{"results": [
+				{"id": "2", "display_name": "snat", "pool_usage": {"total_ids": 10, "allocated_ids": 10, "free_ids": 0}}
+			]}`
+
+			err := CheckSNATIPPool(ctx, client, "snat", "10.250.0.0/16")
+			Expect(err).To(MatchError(`SNAT IP pool "snat" has no free addresses (10 of 10 allocated)`))
+		})
+
+		It("should succeed if the pool has a subnet of the address family of the worker network", func() {
+			responses["/api/v1/pools/ip-pools"] = `{"results": [
+				{"id": "2", "display_name": "snat", "pool_usage": {"free_ids": 1}, "subnets": [{"cidr": "fd00::/64"}, {"cidr": "192.168.0.0/24"}]}
+			]}`
+
+			Expect(CheckSNATIPPool(ctx, client, "snat", "10.250.0.0/16")).To(Succeed())
+		})
+
+		It("should fail if the pool has no subnet of the address family of the worker network", func() {
+			responses["/api/v1/pools/ip-pools"] = `{"results": [
+				{"id": "2", "display_name": "snat", "pool_usage": {"free_ids": 1}, "subnets": [{"cidr": "fd00::/64"}]}
+			]}`
+
+			err := CheckSNATIPPool(ctx, client, "snat", "10.250.0.0/16")
+			Expect(err).To(MatchError(`SNAT IP pool "snat" has no IPv4 subnet for the worker network 10.250.0.0/16`))
+		})
+
+		It("should fail if the pool does not exist", func() {
+			responses["/api/v1/pools/ip-pools"] = `{"results": []}`
+
+			err := CheckSNATIPPool(ctx, client, "snat", "10.250.0.0/16")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`"snat"`))
+		})
+	})
+
+	Describe("#CheckEdgeCluster", func() {
+		BeforeEach(func() {
+			responses["/api/v1/edge-clusters"] = `{"results": [
+				{"id": "ec-1", "display_name": "capable", "members": [{"member_index": 0, "transport_node_id": "tn-1"}, {"member_index": 1, "transport_node_id": "tn-2"}]},
+				{"id": "ec-2", "display_name": "down", "members": [{"member_index": 0, "transport_node_id": "tn-3"}]},
+				{"id": "ec-3", "display_name": "empty", "members": []}
+			]}`
+			responses["/api/v1/edge-clusters/ec-1/status"] = `{"edge_cluster_status": "DEGRADED", "member_status": [
+				{"transport_node_id": "tn-1", "status": "DOWN"}, {"transport_node_id": "tn-2", "status": "UP"}
+			]}`
+			responses["/api/v1/edge-clusters/ec-2/status"] = `{"edge_cluster_status": "DOWN", "member_status": [
+				{"transport_node_id": "tn-3", "status": "DOWN"}
+			]}`
+		})
+
+		It("should succeed if a member of the edge cluster is up", func() {
+			Expect(CheckEdgeCluster(ctx, client, EdgeClusterRef{Name: "capable"})).To(Succeed())
+		})
+
+		It("should fail if no member of the edge cluster is up", func() {
+			err := CheckEdgeCluster(ctx, client, EdgeClusterRef{Name: "down"})
+			Expect(err).To(MatchError(`edge cluster "down" has no member which is up to host the DHCP server (status DOWN)`))
+			Expect(IsEdgeClusterUnavailable(err)).To(BeTrue())
+		})
+
+		It("should fail if the edge cluster has no members", func() {
+			err := CheckEdgeCluster(ctx, client, EdgeClusterRef{Name: "empty"})
+			Expect(err).To(MatchError(`edge cluster "empty" has no members to host the DHCP server`))
+		})
+
+		It("should look up the edge cluster by id if set", func() {
+			responses["/api/v1/edge-clusters/ec-1"] = `{"id": "ec-1", "display_name": "capable", "members": [{"member_index": 0, "transport_node_id": "tn-2"}]}`
+
+			Expect(CheckEdgeCluster(ctx, client, EdgeClusterRef{ID: "ec-1", Name: "down"})).To(Succeed())
+		})
+
+		It("should fail if the edge cluster name is ambiguous", func() {
+			responses["/api/v1/edge-clusters"] = `{"results": [
+				{"id": "ec-1", "display_name": "capable", "members": []},
+				{"id": "ec-4", "display_name": "capable", "members": []}
+			]}`
+
+			err := CheckEdgeCluster(ctx, client, EdgeClusterRef{Name: "capable"})
+			Expect(err).To(MatchError(`could not read edge cluster "capable": edge cluster name "capable" is ambiguous (ids ec-1 and ec-4)`))
+		})
+
+		Describe("#SelectDHCPEdgeCluster", func() {
+			It("should keep the primary edge cluster if a member is up", func() {
+				Expect(SelectDHCPEdgeCluster(ctx, client, EdgeClusterRef{Name: "capable"}, "down")).To(BeEmpty())
+			})
+
+			It("should fail over to the standby edge cluster if no member of the primary is up", func() {
+				Expect(SelectDHCPEdgeCluster(ctx, client, EdgeClusterRef{Name: "down"}, "capable")).To(Equal("capable"))
+			})
+
+			It("should not fail over if the primary edge cluster cannot be read", func() {
+				responses["/api/v1/edge-clusters"] = `{"results": [
+					{"id": "ec-1", "display_name": "capable", "members": [{"member_index": 0, "transport_node_id": "tn-1"}]},
+					{"id": "ec-5", "display_name": "unknown", "members": [{"member_index": 0, "transport_node_id": "tn-5"}]}
+				]}`
+
+				_, err := SelectDHCPEdgeCluster(ctx, client, EdgeClusterRef{Name: "unknown"}, "capable")
+				Expect(err).To(HaveOccurred())
+				Expect(IsEdgeClusterUnavailable(err)).To(BeFalse())
+				Expect(err.Error()).To(HavePrefix(`could not read status of edge cluster "unknown"`))
+			})
+
+			It("should fail if neither edge cluster can host the DHCP server", func() {
+				_, err := SelectDHCPEdgeCluster(ctx, client, EdgeClusterRef{Name: "down"}, "empty")
+				Expect(err).To(MatchError(`could not fail over to standby edge cluster (edge cluster "down" has no member which is up to host the DHCP server (status DOWN)): edge cluster "empty" has no members to host the DHCP server`))
+			})
+		})
+	})
+
+	Describe("TransportZoneChecker", func() {
+		var checker *TransportZoneChecker
+
+		BeforeEach(func() {
+			checker = NewTransportZoneChecker()
+			responses["/api/v1/transport-zones"] = `{"results": [
+				{"id": "1", "display_name": "overlay-tz", "transport_type": "OVERLAY"},
+				{"id": "2", "display_name": "vlan-tz", "transport_type": "VLAN"}
+			]}`
+		})
+
+		It("should succeed for an overlay transport zone", func() {
+			Expect(checker.Check(ctx, client, "nsxt", "overlay-tz")).To(Succeed())
+		})
+
+		It("should fail for a VLAN backed transport zone", func() {
+			err := checker.Check(ctx, client, "nsxt", "vlan-tz")
+			Expect(err).To(MatchError(`transport zone "vlan-tz" must be of type OVERLAY, but is of type VLAN`))
+		})
+
+		It("should fail for an unknown transport zone", func() {
+			err := checker.Check(ctx, client, "nsxt", "unknown-tz")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`"unknown-tz"`))
+		})
+
+		It("should cache the transport type per transport zone", func() {
+			Expect(checker.Check(ctx, client, "nsxt", "overlay-tz")).To(Succeed())
+			Expect(checker.Check(ctx, client, "nsxt", "overlay-tz")).To(Succeed())
+			Expect(checker.Check(ctx, client, "nsxt", "vlan-tz")).NotTo(Succeed())
+			Expect(checker.Check(ctx, client, "nsxt", "vlan-tz")).NotTo(Succeed())
+			Expect(requests).To(Equal(2))
+		})
+	})
+})
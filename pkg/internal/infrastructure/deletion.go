@@ -0,0 +1,33 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletionGracePeriodRemaining returns the time remaining until the given grace period after the given deletion
+// timestamp has elapsed, or zero if it has already elapsed or the deletion was not requested yet.
+func DeletionGracePeriodRemaining(deletionTimestamp *metav1.Time, gracePeriod time.Duration, now time.Time) time.Duration {
+	if deletionTimestamp == nil {
+		return 0
+	}
+	if remaining := deletionTimestamp.Add(gracePeriod).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Deletion", func() {
+	Describe("#DeletionGracePeriodRemaining", func() {
+		var (
+			now               = time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+			deletionTimestamp = metav1.NewTime(now.Add(-2 * time.Minute))
+		)
+
+		It("should defer the deletion until the grace period elapsed", func() {
+			Expect(DeletionGracePeriodRemaining(&deletionTimestamp, 5*time.Minute, now)).To(Equal(3 * time.Minute))
+		})
+
+		It("should not defer the deletion after the grace period elapsed", func() {
+			Expect(DeletionGracePeriodRemaining(&deletionTimestamp, 2*time.Minute, now)).To(BeZero())
+			Expect(DeletionGracePeriodRemaining(&deletionTimestamp, time.Minute, now)).To(BeZero())
+		})
+
+		It("should not defer the deletion without deletion timestamp", func() {
+			Expect(DeletionGracePeriodRemaining(nil, 5*time.Minute, now)).To(BeZero())
+		})
+	})
+})
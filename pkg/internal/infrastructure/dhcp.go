@@ -0,0 +1,320 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	"github.com/pkg/errors"
+)
+
+// dhcpRangeStartOffset is the offset of the first DHCP address in the worker network. The addresses
+// before are reserved for the gateway and the DHCP server.
+const dhcpRangeStartOffset = 10
+
+// IPRange is an inclusive range of IPv4 addresses.
+type IPRange struct {
+	// Start is the first address of the range.
+	Start net.IP
+	// End is the last address of the range.
+	End net.IP
+}
+
+// splitDNSServersByFamily splits the given DNS servers into IPv4 and IPv6 addresses.
+func splitDNSServersByFamily(dnsServers []string) ([]string, []string, error) {
+	var ipv4, ipv6 []string
+	for _, server := range dnsServers {
+		ip := net.ParseIP(server)
+		switch {
+		case ip == nil:
+			return nil, nil, fmt.Errorf("DNS server %q is not a valid IP address", server)
+		case ip.To4() != nil:
+			ipv4 = append(ipv4, server)
+		default:
+			ipv6 = append(ipv6, server)
+		}
+	}
+	return ipv4, ipv6, nil
+}
+
+// dhcpDNSServers returns the DNS servers to be handed out by the IPv4 DHCP server of the worker network.
+// IPv6 DNS servers are left out until dual-stack worker networks are supported, but at least one IPv4
+// DNS server is required if any DNS servers are given.
+func dhcpDNSServers(dnsServers []string) ([]string, error) {
+	ipv4, ipv6, err := splitDNSServersByFamily(dnsServers)
+	if err != nil {
+		return nil, err
+	}
+	if len(ipv4) == 0 && len(ipv6) > 0 {
+		return nil, fmt.Errorf("DNS servers %v contain no IPv4 address for the IPv4 DHCP server of the worker network", ipv6)
+	}
+	return ipv4, nil
+}
+
+// dhcpSearchDomains returns the domain search list handed out by the DHCP server of the worker network. As clients
+// ignore the domain name for searching if a search list is handed out, the domain name is its first entry, followed
+// by the given search domains. Duplicates are only kept at their first position, ignoring case and trailing dots.
+// No search list is handed out if no search domains are given.
+func dhcpSearchDomains(domainName *string, searchDomains []string) []string {
+	if len(searchDomains) == 0 {
+		return nil
+	}
+
+	var domains []string
+	if domainName != nil && *domainName != "" {
+		domains = append(domains, *domainName)
+	}
+	domains = append(domains, searchDomains...)
+
+	var result []string
+	seen := map[string]bool{}
+	for _, domain := range domains {
+		key := strings.ToLower(strings.TrimSuffix(domain, "."))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, domain)
+	}
+	return result
+}
+
+// LookupSNATIPPoolRanges returns the allocation ranges of the SNAT IP pool with the given name.
+func LookupSNATIPPoolRanges(ctx context.Context, client *nsxt.Client, poolName string) ([]IPRange, error) {
+	pool, err := client.FindIPPoolByName(ctx, poolName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read SNAT IP pool %q", poolName)
+	}
+
+	var ranges []IPRange
+	for _, subnet := range pool.Subnets {
+		for _, r := range subnet.AllocationRanges {
+			start, end := net.ParseIP(r.Start).To4(), net.ParseIP(r.End).To4()
+			if start == nil || end == nil {
+				return nil, fmt.Errorf("SNAT IP pool %q has invalid IPv4 allocation range %s-%s", poolName, r.Start, r.End)
+			}
+			ranges = append(ranges, IPRange{Start: start, End: end})
+		}
+	}
+	return ranges, nil
+}
+
+// DHCPAllocationRanges returns the address ranges of the given worker network which are handed out by the DHCP server
+// for the given InfrastructureConfig without the additionally given excluded ranges.
+func DHCPAllocationRanges(config *api.InfrastructureConfig, workers string, excluded []IPRange) ([]IPRange, error) {
+	dhcpRanges, err := parseAddressRanges(config.DHCPRanges)
+	if err != nil {
+		return nil, err
+	}
+	excludedRanges, err := parseAddressRanges(config.DHCPExcludedRanges)
+	if err != nil {
+		return nil, err
+	}
+	ranges, _, err := computeDHCPRanges(workers, dhcpRanges, append(excludedRanges, excluded...))
+	return ranges, err
+}
+
+// CheckDHCPLeases checks that the addresses of the active leases of the logical DHCP server with the given id are
+// within the given allocation ranges, so that resizing the worker network or changing the DHCP ranges does not
+// take away addresses which are still in use. It succeeds if the leases are not available (yet).
+func CheckDHCPLeases(ctx context.Context, client *nsxt.Client, serverID string, ranges []IPRange) error {
+	leases, err := client.GetDHCPLeases(ctx, serverID)
+	if err != nil {
+		if err == nsxt.ErrDHCPLeasesNotAvailable {
+			return nil
+		}
+		return errors.Wrapf(err, "could not read the leases of DHCP server %s", serverID)
+	}
+
+	var outside []string
+	for _, lease := range leases {
+		ip := net.ParseIP(lease.IPAddress).To4()
+		if ip == nil || !rangesContain(ranges, ip) {
+			outside = append(outside, lease.IPAddress)
+		}
+	}
+	if len(outside) > 0 {
+		sort.Strings(outside)
+		return fmt.Errorf("the DHCP ranges must contain the addresses of all active leases, but do not contain %s", strings.Join(outside, ", "))
+	}
+	return nil
+}
+
+func rangesContain(ranges []IPRange, ip net.IP) bool {
+	n := ipToUint32(ip)
+	for _, r := range ranges {
+		if ipToUint32(r.Start) <= n && n <= ipToUint32(r.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// computeDHCPRanges computes the DHCP allocation ranges of the given worker network without the given excluded ranges.
+// If no ranges are given, the allocation range spans from the dhcpRangeStartOffset-th to the last address of the network.
+// It returns whether the ranges differ from this default allocation range.
+func computeDHCPRanges(workers string, ranges, excluded []IPRange) ([]IPRange, bool, error) {
+	first, last, err := networkRange(workers)
+	if err != nil {
+		return nil, false, err
+	}
+	if last-first < dhcpRangeStartOffset {
+		return nil, false, fmt.Errorf("worker network %s is too small", workers)
+	}
+
+	type uintRange struct{ start, end uint32 }
+	allocationRanges := []uintRange{{first + dhcpRangeStartOffset, last}}
+	changed := false
+	if len(ranges) > 0 {
+		allocationRanges = nil
+		changed = true
+		// the network address, the gateway and the DHCP server address must not be handed out
+		for _, r := range ranges {
+			start, end := ipToUint32(r.Start), ipToUint32(r.End)
+			if start < first+3 || end > last || start > end {
+				return nil, false, fmt.Errorf("DHCP range %s-%s must be within worker network %s without its first three addresses", r.Start, r.End, workers)
+			}
+			allocationRanges = append(allocationRanges, uintRange{start, end})
+		}
+	}
+	for _, ex := range excluded {
+		exStart, exEnd := ipToUint32(ex.Start), ipToUint32(ex.End)
+		var remaining []uintRange
+		for _, r := range allocationRanges {
+			if exEnd < r.start || exStart > r.end {
+				remaining = append(remaining, r)
+				continue
+			}
+			changed = true
+			if exStart > r.start {
+				remaining = append(remaining, uintRange{r.start, exStart - 1})
+			}
+			if exEnd < r.end {
+				remaining = append(remaining, uintRange{exEnd + 1, r.end})
+			}
+		}
+		allocationRanges = remaining
+	}
+	if len(allocationRanges) == 0 {
+		return nil, false, fmt.Errorf("no DHCP addresses left in worker network %s after excluding ranges", workers)
+	}
+
+	result := make([]IPRange, 0, len(allocationRanges))
+	for _, r := range allocationRanges {
+		result = append(result, IPRange{Start: uint32ToIP(r.start), End: uint32ToIP(r.end)})
+	}
+	return result, changed, nil
+}
+
+// checkDHCPServerIP checks that the given DHCP server IP is a host address of the given worker network other than
+// the gateway address, and that it is not contained in the given DHCP ranges.
+func checkDHCPServerIP(workers, serverIP string, ranges []IPRange) error {
+	ip := net.ParseIP(serverIP).To4()
+	if ip == nil {
+		return fmt.Errorf("DHCP server IP %s is not an IPv4 address", serverIP)
+	}
+	first, last, err := networkRange(workers)
+	if err != nil {
+		return err
+	}
+
+	n := ipToUint32(ip)
+	if n <= first+1 || n >= last {
+		return fmt.Errorf("DHCP server IP %s must be a host address of worker network %s other than the gateway", serverIP, workers)
+	}
+	for _, r := range ranges {
+		if n >= ipToUint32(r.Start) && n <= ipToUint32(r.End) {
+			return fmt.Errorf("DHCP server IP %s must not be within DHCP range %s-%s", serverIP, r.Start, r.End)
+		}
+	}
+	return nil
+}
+
+// checkDHCPClientGateway checks that the given client gateway is a host address of the given worker network, that it
+// is not the address of the DHCP server, and that it is not contained in the given DHCP ranges.
+func checkDHCPClientGateway(workers, gateway string, serverIP *string, ranges []IPRange) error {
+	ip := net.ParseIP(gateway).To4()
+	if ip == nil {
+		return fmt.Errorf("DHCP client gateway %s is not an IPv4 address", gateway)
+	}
+	first, last, err := networkRange(workers)
+	if err != nil {
+		return err
+	}
+
+	n := ipToUint32(ip)
+	if n <= first || n >= last {
+		return fmt.Errorf("DHCP client gateway %s must be a host address of worker network %s", gateway, workers)
+	}
+	server := first + 2
+	if serverIP != nil {
+		server = ipToUint32(net.ParseIP(*serverIP))
+	}
+	if n == server {
+		return fmt.Errorf("DHCP client gateway %s must not be the address of the DHCP server", gateway)
+	}
+	for _, r := range ranges {
+		if n >= ipToUint32(r.Start) && n <= ipToUint32(r.End) {
+			return fmt.Errorf("DHCP client gateway %s must not be within DHCP range %s-%s", gateway, r.Start, r.End)
+		}
+	}
+	return nil
+}
+
+// checkRangesWithinNetwork checks that the given DHCP excluded ranges are within the given worker network, as
+// ranges outside of it do not exclude any address, e.g. if an address range of load balancer VIPs is mistyped.
+func checkRangesWithinNetwork(workers string, ranges []IPRange) error {
+	first, last, err := networkRange(workers)
+	if err != nil {
+		return err
+	}
+	for _, r := range ranges {
+		if ipToUint32(r.Start) < first || ipToUint32(r.End) > last {
+			return fmt.Errorf("DHCP excluded range %s-%s must be within worker network %s", r.Start, r.End, workers)
+		}
+	}
+	return nil
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uint32ToIP(n uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
+
+// parseAddressRanges parses the given address ranges of the InfrastructureConfig.
+func parseAddressRanges(ranges []api.AddressRange) ([]IPRange, error) {
+	var result []IPRange
+	for _, r := range ranges {
+		start, end := net.ParseIP(r.Start).To4(), net.ParseIP(r.End).To4()
+		if start == nil || end == nil {
+			return nil, fmt.Errorf("invalid IPv4 address range %s-%s", r.Start, r.End)
+		}
+		result = append(result, IPRange{Start: start, End: end})
+	}
+	return result, nil
+}
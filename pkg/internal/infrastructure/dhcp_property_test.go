@@ -0,0 +1,88 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"net"
+	"testing/quick"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DHCP addressing properties", func() {
+	// randomNetwork returns a random IPv4 network with a prefix length between /8 and /28.
+	randomNetwork := func(ip uint32, prefix uint8) (string, uint32, uint32) {
+		mask := net.CIDRMask(8+int(prefix%21), 32)
+		network := &net.IPNet{IP: uint32ToIP(ip).Mask(mask), Mask: mask}
+		first, last, err := networkRange(network.String())
+		Expect(err).NotTo(HaveOccurred())
+		return network.String(), first, last
+	}
+
+	It("should compute the first and the last address of networks", func() {
+		property := func(ip uint32, prefix uint8) bool {
+			workers, first, last := randomNetwork(ip, prefix)
+			_, network, _ := net.ParseCIDR(workers)
+			return first <= last && network.Contains(uint32ToIP(first)) && network.Contains(uint32ToIP(last)) &&
+				(first == 0 || !network.Contains(uint32ToIP(first-1))) &&
+				(last == ^uint32(0) || !network.Contains(uint32ToIP(last+1)))
+		}
+		Expect(quick.Check(property, nil)).To(Succeed())
+	})
+
+	It("should only hand out addresses of the worker network without the reserved and excluded ones", func() {
+		property := func(ip uint32, prefix uint8, offset, length uint16) bool {
+			workers, first, last := randomNetwork(ip, prefix)
+			exStart := first + uint32(offset)%(last-first+1)
+			exEnd := exStart + uint32(length)
+			if exEnd < exStart || exEnd > last {
+				exEnd = last
+			}
+			excluded := IPRange{Start: uint32ToIP(exStart), End: uint32ToIP(exEnd)}
+
+			ranges, _, err := computeDHCPRanges(workers, nil, []IPRange{excluded})
+			if err != nil {
+				// only allowed if the excluded range covers all addresses of the default range
+				return exStart <= first+dhcpRangeStartOffset && exEnd == last
+			}
+
+			previousEnd := first + dhcpRangeStartOffset - 1
+			for _, r := range ranges {
+				start, end := ipToUint32(r.Start), ipToUint32(r.End)
+				if start <= previousEnd || start > end || end > last {
+					return false
+				}
+				if start <= exEnd && exStart <= end {
+					return false
+				}
+				previousEnd = end
+			}
+			return true
+		}
+		Expect(quick.Check(property, nil)).To(Succeed())
+	})
+
+	It("should accept exactly the host addresses other than the gateway as DHCP server IP", func() {
+		property := func(ip uint32, prefix uint8, offset uint16) bool {
+			workers, first, last := randomNetwork(ip, prefix)
+			n := first + uint32(offset)%(last-first+1)
+
+			err := checkDHCPServerIP(workers, uint32ToIP(n).String(), nil)
+			return (err == nil) == (n > first+1 && n < last)
+		}
+		Expect(quick.Check(property, nil)).To(Succeed())
+	})
+})
@@ -0,0 +1,316 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DHCP", func() {
+	ipRange := func(start, end string) IPRange {
+		return IPRange{Start: net.ParseIP(start).To4(), End: net.ParseIP(end).To4()}
+	}
+
+	Describe("#computeDHCPRanges", func() {
+		It("should not change the default range if the excluded ranges are outside", func() {
+			ranges, changed, err := computeDHCPRanges("10.250.0.0/24", nil, []IPRange{ipRange("10.250.1.0", "10.250.1.10")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(ranges).To(Equal([]IPRange{ipRange("10.250.0.10", "10.250.0.255")}))
+		})
+
+		It("should split the default range around an excluded range", func() {
+			ranges, changed, err := computeDHCPRanges("10.250.0.0/24", nil, []IPRange{ipRange("10.250.0.100", "10.250.0.100")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(ranges).To(Equal([]IPRange{
+				ipRange("10.250.0.10", "10.250.0.99"),
+				ipRange("10.250.0.101", "10.250.0.255"),
+			}))
+		})
+
+		It("should shrink the default range by overlapping excluded ranges", func() {
+			ranges, changed, err := computeDHCPRanges("10.250.0.0/24", nil, []IPRange{
+				ipRange("10.250.0.0", "10.250.0.19"),
+				ipRange("10.250.0.200", "10.250.1.50"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(ranges).To(Equal([]IPRange{ipRange("10.250.0.20", "10.250.0.199")}))
+		})
+
+		It("should use the given ranges without the excluded ranges", func() {
+			ranges, changed, err := computeDHCPRanges("10.250.0.0/24",
+				[]IPRange{ipRange("10.250.0.3", "10.250.0.50"), ipRange("10.250.0.100", "10.250.0.150")},
+				[]IPRange{ipRange("10.250.0.40", "10.250.0.120")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(ranges).To(Equal([]IPRange{
+				ipRange("10.250.0.3", "10.250.0.39"),
+				ipRange("10.250.0.121", "10.250.0.150"),
+			}))
+		})
+
+		It("should fail for ranges outside of the worker network or containing reserved addresses", func() {
+			for _, r := range []IPRange{
+				ipRange("10.250.0.2", "10.250.0.50"),
+				ipRange("10.250.0.200", "10.250.1.10"),
+				ipRange("10.250.0.50", "10.250.0.40"),
+			} {
+				_, _, err := computeDHCPRanges("10.250.0.0/24", []IPRange{r}, nil)
+				Expect(err).To(HaveOccurred())
+			}
+		})
+
+		It("should fail if no addresses are left", func() {
+			_, _, err := computeDHCPRanges("10.250.0.0/24", nil, []IPRange{ipRange("10.250.0.0", "10.250.0.255")})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for invalid worker networks", func() {
+			_, _, err := computeDHCPRanges("10.250.0.0", nil, nil)
+			Expect(err).To(HaveOccurred())
+
+			_, _, err = computeDHCPRanges("fd00::/64", nil, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#checkDHCPServerIP", func() {
+		ranges := []IPRange{ipRange("10.250.0.10", "10.250.0.255")}
+
+		It("should accept host addresses outside of the DHCP ranges", func() {
+			Expect(checkDHCPServerIP("10.250.0.0/24", "10.250.0.2", ranges)).To(Succeed())
+			Expect(checkDHCPServerIP("10.250.0.0/24", "10.250.0.9", ranges)).To(Succeed())
+		})
+
+		It("should forbid addresses outside of the worker network", func() {
+			Expect(checkDHCPServerIP("10.250.0.0/24", "10.250.1.2", ranges)).NotTo(Succeed())
+		})
+
+		It("should forbid the network and gateway address", func() {
+			Expect(checkDHCPServerIP("10.250.0.0/24", "10.250.0.0", ranges)).NotTo(Succeed())
+			Expect(checkDHCPServerIP("10.250.0.0/24", "10.250.0.1", ranges)).NotTo(Succeed())
+		})
+
+		It("should forbid addresses within the DHCP ranges", func() {
+			Expect(checkDHCPServerIP("10.250.0.0/24", "10.250.0.10", ranges)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#checkDHCPClientGateway", func() {
+		ranges := []IPRange{ipRange("10.250.0.10", "10.250.0.255")}
+
+		It("should accept host addresses outside of the DHCP ranges", func() {
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.1", nil, ranges)).To(Succeed())
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.3", nil, ranges)).To(Succeed())
+		})
+
+		It("should forbid addresses outside of the worker network", func() {
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.1.3", nil, ranges)).NotTo(Succeed())
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.0", nil, ranges)).NotTo(Succeed())
+		})
+
+		It("should forbid the address of the DHCP server", func() {
+			serverIP := "10.250.0.5"
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.2", nil, ranges)).NotTo(Succeed())
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.2", &serverIP, ranges)).To(Succeed())
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.5", &serverIP, ranges)).NotTo(Succeed())
+		})
+
+		It("should forbid addresses within the DHCP ranges", func() {
+			Expect(checkDHCPClientGateway("10.250.0.0/24", "10.250.0.10", nil, ranges)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#dhcpDNSServers", func() {
+		It("should only pass the IPv4 DNS servers of mixed lists", func() {
+			servers, err := dhcpDNSServers([]string{"10.10.10.11", "fd00::1", "10.10.10.12"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(servers).To(Equal([]string{"10.10.10.11", "10.10.10.12"}))
+		})
+
+		It("should pass IPv4 only lists", func() {
+			servers, err := dhcpDNSServers([]string{"10.10.10.11", "10.10.10.12"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(servers).To(Equal([]string{"10.10.10.11", "10.10.10.12"}))
+		})
+
+		It("should fail for IPv6 only lists", func() {
+			_, err := dhcpDNSServers([]string{"fd00::1", "fd00::2"})
+			Expect(err).To(MatchError("DNS servers [fd00::1 fd00::2] contain no IPv4 address for the IPv4 DHCP server of the worker network"))
+		})
+
+		It("should fail for invalid addresses", func() {
+			_, err := dhcpDNSServers([]string{"10.10.10.11", "foo"})
+			Expect(err).To(MatchError(`DNS server "foo" is not a valid IP address`))
+		})
+	})
+
+	Describe("#dhcpSearchDomains", func() {
+		domain := func(name string) *string { return &name }
+
+		table.DescribeTable("should order the cluster domain before the custom search domains",
+			func(domainName *string, searchDomains, expected []string) {
+				Expect(dhcpSearchDomains(domainName, searchDomains)).To(Equal(expected))
+			},
+			table.Entry("no domains", nil, nil, nil),
+			table.Entry("only cluster domain", domain("cluster.example.com"), nil, nil),
+			table.Entry("only search domains", nil, []string{"example.com", "svc.example.com"}, []string{"example.com", "svc.example.com"}),
+			table.Entry("empty cluster domain", domain(""), []string{"example.com"}, []string{"example.com"}),
+			table.Entry("cluster and search domains", domain("cluster.example.com"), []string{"example.com", "svc.example.com"},
+				[]string{"cluster.example.com", "example.com", "svc.example.com"}),
+			table.Entry("cluster domain in search domains", domain("cluster.example.com"), []string{"example.com", "cluster.example.com"},
+				[]string{"cluster.example.com", "example.com"}),
+			table.Entry("duplicate search domains", nil, []string{"example.com", "svc.example.com", "Example.com."},
+				[]string{"example.com", "svc.example.com"}),
+		)
+	})
+
+	Describe("#LookupSNATIPPoolRanges", func() {
+		var (
+			ctx      = context.TODO()
+			server   *httptest.Server
+			client   *nsxt.Client
+			response string
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(response))
+			}))
+			client = nsxt.NewClient(server.URL, "user", "password", true)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should return the allocation ranges of all subnets", func() {
+			response = `{"results": [{"id": "1", "display_name": "snat", "subnets": [
+				{"cidr": "10.0.0.0/24", "allocation_ranges": [{"start": "10.0.0.10", "end": "10.0.0.20"}, {"start": "10.0.0.30", "end": "10.0.0.30"}]},
+				{"cidr": "10.0.1.0/24", "allocation_ranges": [{"start": "10.0.1.10", "end": "10.0.1.20"}]}
+			]}]}`
+
+			ranges, err := LookupSNATIPPoolRanges(ctx, client, "snat")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal([]IPRange{
+				ipRange("10.0.0.10", "10.0.0.20"),
+				ipRange("10.0.0.30", "10.0.0.30"),
+				ipRange("10.0.1.10", "10.0.1.20"),
+			}))
+		})
+
+		It("should fail for non IPv4 allocation ranges", func() {
+			response = `{"results": [{"id": "1", "display_name": "snat", "subnets": [
+				{"cidr": "fd00::/64", "allocation_ranges": [{"start": "fd00::10", "end": "fd00::20"}]}
+			]}]}`
+
+			_, err := LookupSNATIPPoolRanges(ctx, client, "snat")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#DHCPAllocationRanges", func() {
+		It("should return the default range of the worker network", func() {
+			ranges, err := DHCPAllocationRanges(&api.InfrastructureConfig{}, "10.250.0.0/23", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal([]IPRange{ipRange("10.250.0.10", "10.250.1.255")}))
+		})
+
+		It("should consider the configured and the additionally excluded ranges", func() {
+			config := &api.InfrastructureConfig{
+				DHCPRanges:         []api.AddressRange{{Start: "10.250.0.100", End: "10.250.0.200"}},
+				DHCPExcludedRanges: []api.AddressRange{{Start: "10.250.0.150", End: "10.250.0.160"}},
+			}
+
+			ranges, err := DHCPAllocationRanges(config, "10.250.0.0/24", []IPRange{ipRange("10.250.0.190", "10.250.0.250")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal([]IPRange{ipRange("10.250.0.100", "10.250.0.149"), ipRange("10.250.0.161", "10.250.0.189")}))
+		})
+	})
+
+	Describe("#CheckDHCPLeases", func() {
+		var (
+			ctx      = context.TODO()
+			server   *httptest.Server
+			client   *nsxt.Client
+			response string
+		)
+
+		BeforeEach(func() {
+			response = `{"leases": [
+				{"ip_address": "10.250.0.10", "mac_address": "00:50:56:00:00:01"},
+				{"ip_address": "10.250.0.255", "mac_address": "00:50:56:00:00:02"}
+			]}`
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/dhcp/servers/server1/leases" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(response))
+			}))
+			client = nsxt.NewClient(server.URL, "user", "password", true)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should succeed if the worker network is enlarged", func() {
+			ranges, err := DHCPAllocationRanges(&api.InfrastructureConfig{}, "10.250.0.0/23", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(CheckDHCPLeases(ctx, client, "server1", ranges)).To(Succeed())
+		})
+
+		It("should fail if the worker network is shrunk below the leased addresses", func() {
+			response = `{"leases": [
+				{"ip_address": "10.250.1.20", "mac_address": "00:50:56:00:00:03"},
+				{"ip_address": "10.250.0.10", "mac_address": "00:50:56:00:00:01"},
+				{"ip_address": "10.250.1.10", "mac_address": "00:50:56:00:00:02"}
+			]}`
+			ranges, err := DHCPAllocationRanges(&api.InfrastructureConfig{}, "10.250.0.0/24", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(CheckDHCPLeases(ctx, client, "server1", ranges)).To(MatchError(
+				"the DHCP ranges must contain the addresses of all active leases, but do not contain 10.250.1.10, 10.250.1.20"))
+		})
+
+		It("should fail if the DHCP ranges exclude leased addresses", func() {
+			config := &api.InfrastructureConfig{DHCPRanges: []api.AddressRange{{Start: "10.250.0.100", End: "10.250.0.255"}}}
+			ranges, err := DHCPAllocationRanges(config, "10.250.0.0/24", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(CheckDHCPLeases(ctx, client, "server1", ranges)).To(MatchError(ContainSubstring("do not contain 10.250.0.10")))
+		})
+
+		It("should fail if the leases cannot be read", func() {
+			Expect(CheckDHCPLeases(ctx, client, "unknown", nil)).NotTo(Succeed())
+		})
+	})
+})
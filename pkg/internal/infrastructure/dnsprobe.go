@@ -0,0 +1,75 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// DefaultDNSServerProbeTimeout is the default timeout of a probe of a DNS server.
+const DefaultDNSServerProbeTimeout = 2 * time.Second
+
+// DNSServerProber probes the reachability of DNS servers from the seed by connecting to their TCP port 53.
+type DNSServerProber struct {
+	timeout time.Duration
+	dial    func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// NewDNSServerProber creates a new DNSServerProber waiting for each connection up to the given timeout.
+func NewDNSServerProber(timeout time.Duration) *DNSServerProber {
+	dialer := &net.Dialer{}
+	return &DNSServerProber{
+		timeout: timeout,
+		dial:    dialer.DialContext,
+	}
+}
+
+// Unreachable probes the given DNS servers concurrently and returns the ones which could not be connected to within
+// the timeout, in the given order.
+func (p *DNSServerProber) Unreachable(ctx context.Context, servers []string) []string {
+	reachable := make([]bool, len(servers))
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			reachable[i] = p.probe(ctx, server)
+		}(i, server)
+	}
+	wg.Wait()
+
+	var unreachable []string
+	for i, server := range servers {
+		if !reachable[i] {
+			unreachable = append(unreachable, server)
+		}
+	}
+	return unreachable
+}
+
+func (p *DNSServerProber) probe(ctx context.Context, server string) bool {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	conn, err := p.dial(ctx, "tcp", net.JoinHostPort(server, "53"))
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
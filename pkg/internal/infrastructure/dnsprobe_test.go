@@ -0,0 +1,86 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DNSServerProber", func() {
+	var (
+		ctx       = context.TODO()
+		prober    *DNSServerProber
+		reachable map[string]bool
+		addresses []string
+		lock      sync.Mutex
+	)
+
+	BeforeEach(func() {
+		reachable = map[string]bool{}
+		addresses = nil
+		prober = NewDNSServerProber(100 * time.Millisecond)
+		prober.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+			lock.Lock()
+			addresses = append(addresses, network+"/"+address)
+			lock.Unlock()
+			host, _, _ := net.SplitHostPort(address)
+			if !reachable[host] {
+				<-ctx.Done()
+				return nil, fmt.Errorf("dial %s: %v", address, ctx.Err())
+			}
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		}
+	})
+
+	It("should not report reachable DNS servers", func() {
+		reachable["10.10.10.11"] = true
+
+		Expect(prober.Unreachable(ctx, []string{"10.10.10.11"})).To(BeEmpty())
+		Expect(addresses).To(ConsistOf("tcp/10.10.10.11:53"))
+	})
+
+	It("should report unreachable DNS servers after the timeout", func() {
+		reachable["10.10.10.12"] = true
+
+		start := time.Now()
+		Expect(prober.Unreachable(ctx, []string{"10.10.10.11", "10.10.10.12", "10.10.10.13"})).To(Equal([]string{"10.10.10.11", "10.10.10.13"}))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+
+	It("should probe a real listener", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+		_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+		prober = NewDNSServerProber(time.Second)
+		dial := prober.dial
+		prober.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+			host, _, _ := net.SplitHostPort(address)
+			return dial(ctx, network, net.JoinHostPort(host, port))
+		}
+
+		Expect(prober.Unreachable(ctx, []string{"127.0.0.1"})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,60 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"sync"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// InsecureSSLWarner logs a warning if the TLS certificate of the NSX-T manager of a region is not verified.
+// The warning is logged once per region and NSX-T host, so that it is visible without flooding the log.
+type InsecureSSLWarner struct {
+	logger logr.InfoLogger
+
+	lock   sync.Mutex
+	warned sets.String
+}
+
+// NewInsecureSSLWarner creates a new InsecureSSLWarner logging to the given logger.
+func NewInsecureSSLWarner(logger logr.InfoLogger) *InsecureSSLWarner {
+	return &InsecureSSLWarner{
+		logger: logger,
+		warned: sets.NewString(),
+	}
+}
+
+// Warn logs a warning if insecure SSL is in effect for the NSX-T manager of the given region and it was not logged
+// for the region before. It returns whether the warning was logged.
+func (w *InsecureSSLWarner) Warn(region *api.RegionSpec) bool {
+	if !region.NSXTInsecureSSL {
+		return false
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	key := region.Name + "/" + region.NSXTHost
+	if w.warned.Has(key) {
+		return false
+	}
+	w.warned.Insert(key)
+	w.logger.Info("WARNING: the TLS certificate of the NSX-T manager is not verified (nsxtInsecureSSL)", "region", region.Name, "host", region.NSXTHost)
+	return true
+}
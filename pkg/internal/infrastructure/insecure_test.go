@@ -0,0 +1,80 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"sync"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeInfoLogger struct {
+	lock     sync.Mutex
+	messages []string
+}
+
+func (l *fakeInfoLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.messages = append(l.messages, msg)
+}
+
+func (l *fakeInfoLogger) Enabled() bool {
+	return true
+}
+
+var _ = Describe("InsecureSSLWarner", func() {
+	var (
+		logger *fakeInfoLogger
+		warner *InsecureSSLWarner
+	)
+
+	BeforeEach(func() {
+		logger = &fakeInfoLogger{}
+		warner = NewInsecureSSLWarner(logger)
+	})
+
+	It("should warn exactly once per region", func() {
+		region1 := &api.RegionSpec{Name: "region1", NSXTHost: "nsxt1", NSXTInsecureSSL: true}
+		region2 := &api.RegionSpec{Name: "region2", NSXTHost: "nsxt2", NSXTInsecureSSL: true}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				warner.Warn(region1)
+			}()
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				warner.Warn(region2)
+			}()
+		}
+		wg.Wait()
+
+		Expect(logger.messages).To(HaveLen(2))
+		Expect(warner.Warn(region1)).To(BeFalse())
+	})
+
+	It("should not warn for verified connections", func() {
+		Expect(warner.Warn(&api.RegionSpec{Name: "region1", NSXTHost: "nsxt1"})).To(BeFalse())
+		Expect(logger.messages).To(BeEmpty())
+	})
+})
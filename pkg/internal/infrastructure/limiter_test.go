@@ -0,0 +1,69 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConcurrencyLimiter", func() {
+	It("should bound the number of concurrent operations", func() {
+		limiter := NewConcurrencyLimiter(2)
+
+		var running, maxRunning int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				Expect(limiter.Acquire(context.TODO())).To(Succeed())
+				defer limiter.Release()
+
+				current := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}()
+		}
+		wg.Wait()
+
+		Expect(atomic.LoadInt32(&maxRunning)).To(Equal(int32(2)))
+	})
+
+	It("should stop waiting if the context is done", func() {
+		limiter := NewConcurrencyLimiter(1)
+		Expect(limiter.Acquire(context.TODO())).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+		Expect(limiter.Acquire(ctx)).To(MatchError(context.DeadlineExceeded))
+
+		limiter.Release()
+		Expect(limiter.Acquire(context.TODO())).To(Succeed())
+	})
+})
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DHCPPoolUsageSource reads the usage statistics of DHCP IP pools, e.g. the NSX-T client.
+type DHCPPoolUsageSource interface {
+	// GetDHCPIPPoolUsage returns the usage statistics of the IP pool with the given id of the logical DHCP server
+	// with the given id.
+	GetDHCPIPPoolUsage(ctx context.Context, serverID, poolID string) (*nsxt.DHCPIPPoolUsage, error)
+}
+
+// DHCPPoolMetrics exposes the utilization of the DHCP IP pools of the worker networks as Prometheus gauges
+// labeled by the cluster. The gauges are updated by the periodic poll of the DHCP IP pools, so that they do not go
+// stale between the reconciliations.
+type DHCPPoolMetrics struct {
+	used        *prometheus.GaugeVec
+	total       *prometheus.GaugeVec
+	utilization *prometheus.GaugeVec
+}
+
+// NewDHCPPoolMetrics creates new DHCPPoolMetrics.
+func NewDHCPPoolMetrics() *DHCPPoolMetrics {
+	newGaugeVec := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, []string{"cluster"})
+	}
+	return &DHCPPoolMetrics{
+		used:        newGaugeVec("vsphere_dhcp_pool_used", "Number of allocated addresses of the DHCP IP pool of the worker network."),
+		total:       newGaugeVec("vsphere_dhcp_pool_total", "Number of addresses of the DHCP IP pool of the worker network."),
+		utilization: newGaugeVec("vsphere_dhcp_pool_utilization", "Percentage of allocated addresses of the DHCP IP pool of the worker network."),
+	}
+}
+
+// Register registers the gauges with the given registerer.
+func (m *DHCPPoolMetrics) Register(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{m.used, m.total, m.utilization} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update reads the usage statistics of the DHCP IP pool with the given ids from the given source and sets the gauges
+// of the given cluster. If the statistics are not available, the gauges of the cluster are removed until the next
+// successful update, so that no stale values are exposed.
+func (m *DHCPPoolMetrics) Update(ctx context.Context, source DHCPPoolUsageSource, cluster, serverID, poolID string) error {
+	usage, err := source.GetDHCPIPPoolUsage(ctx, serverID, poolID)
+	if err != nil {
+		m.Forget(cluster)
+		return err
+	}
+
+	m.used.WithLabelValues(cluster).Set(float64(usage.AllocatedNumber))
+	m.total.WithLabelValues(cluster).Set(float64(usage.PoolSize))
+	m.utilization.WithLabelValues(cluster).Set(usage.AllocatedPercentage)
+	return nil
+}
+
+// Forget removes the gauges of the given cluster.
+func (m *DHCPPoolMetrics) Forget(cluster string) {
+	m.used.DeleteLabelValues(cluster)
+	m.total.DeleteLabelValues(cluster)
+	m.utilization.DeleteLabelValues(cluster)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+type fakeDHCPPoolUsageSource map[string]*nsxt.DHCPIPPoolUsage
+
+func (f fakeDHCPPoolUsageSource) GetDHCPIPPoolUsage(_ context.Context, serverID, poolID string) (*nsxt.DHCPIPPoolUsage, error) {
+	usage, ok := f[serverID+"/"+poolID]
+	if !ok {
+		return nil, errors.New("statistics not available")
+	}
+	return usage, nil
+}
+
+var _ = Describe("DHCPPoolMetrics", func() {
+	var (
+		ctx      = context.TODO()
+		metrics  *DHCPPoolMetrics
+		registry *prometheus.Registry
+		source   fakeDHCPPoolUsageSource
+	)
+
+	gauges := func() map[string]map[string]float64 {
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+
+		result := map[string]map[string]float64{}
+		for _, family := range families {
+			values := map[string]float64{}
+			for _, metric := range family.GetMetric() {
+				values[labelValue(metric, "cluster")] = metric.GetGauge().GetValue()
+			}
+			result[family.GetName()] = values
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		metrics = NewDHCPPoolMetrics()
+		registry = prometheus.NewRegistry()
+		Expect(metrics.Register(registry)).To(Succeed())
+		source = fakeDHCPPoolUsageSource{
+			"server1/pool1": {PoolSize: 200, AllocatedNumber: 50, AllocatedPercentage: 25},
+			"server2/pool2": {PoolSize: 100, AllocatedNumber: 90, AllocatedPercentage: 90},
+		}
+	})
+
+	It("should expose the usage of the DHCP IP pools by cluster", func() {
+		Expect(metrics.Update(ctx, source, "shoot--foo--bar", "server1", "pool1")).To(Succeed())
+		Expect(metrics.Update(ctx, source, "shoot--foo--baz", "server2", "pool2")).To(Succeed())
+
+		Expect(gauges()).To(Equal(map[string]map[string]float64{
+			"vsphere_dhcp_pool_used":        {"shoot--foo--bar": 50, "shoot--foo--baz": 90},
+			"vsphere_dhcp_pool_total":       {"shoot--foo--bar": 200, "shoot--foo--baz": 100},
+			"vsphere_dhcp_pool_utilization": {"shoot--foo--bar": 25, "shoot--foo--baz": 90},
+		}))
+	})
+
+	It("should remove the gauges of a cluster while its statistics are unavailable", func() {
+		Expect(metrics.Update(ctx, source, "shoot--foo--bar", "server1", "pool1")).To(Succeed())
+		Expect(metrics.Update(ctx, source, "shoot--foo--baz", "server2", "pool2")).To(Succeed())
+
+		delete(source, "server1/pool1")
+		Expect(metrics.Update(ctx, source, "shoot--foo--bar", "server1", "pool1")).To(MatchError("statistics not available"))
+		Expect(gauges()).To(Equal(map[string]map[string]float64{
+			"vsphere_dhcp_pool_used":        {"shoot--foo--baz": 90},
+			"vsphere_dhcp_pool_total":       {"shoot--foo--baz": 100},
+			"vsphere_dhcp_pool_utilization": {"shoot--foo--baz": 90},
+		}))
+
+		source["server1/pool1"] = &nsxt.DHCPIPPoolUsage{PoolSize: 200, AllocatedNumber: 60, AllocatedPercentage: 30}
+		Expect(metrics.Update(ctx, source, "shoot--foo--bar", "server1", "pool1")).To(Succeed())
+		Expect(gauges()["vsphere_dhcp_pool_used"]).To(HaveKeyWithValue("shoot--foo--bar", float64(60)))
+	})
+
+	It("should remove the gauges of a forgotten cluster", func() {
+		Expect(metrics.Update(ctx, source, "shoot--foo--bar", "server1", "pool1")).To(Succeed())
+
+		metrics.Forget("shoot--foo--bar")
+		Expect(gauges()).To(BeEmpty())
+	})
+})
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// nsxtReservedNetworks are the default internal transit subnets of NSX-T between tier-0 and tier-1 routers and
+// between the service and distributed router components of tier-0 routers.
+var nsxtReservedNetworks = []string{"100.64.0.0/16", "169.254.0.0/24"}
+
+// privateNetworks are the private IPv4 address ranges of RFC 1918.
+var privateNetworks = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// CheckWorkerNetwork checks that the given worker network neither overlaps the internal transit subnets of NSX-T
+// nor the given allocation ranges of the SNAT IP pool.
+func CheckWorkerNetwork(workers string, snatRanges []IPRange) error {
+	first, last, err := networkRange(workers)
+	if err != nil {
+		return err
+	}
+
+	for _, reserved := range nsxtReservedNetworks {
+		reservedFirst, reservedLast, err := networkRange(reserved)
+		if err != nil {
+			return err
+		}
+		if first <= reservedLast && reservedFirst <= last {
+			return fmt.Errorf("worker network %s overlaps the NSX-T reserved network %s", workers, reserved)
+		}
+	}
+	for _, r := range snatRanges {
+		if first <= ipToUint32(r.End) && ipToUint32(r.Start) <= last {
+			return fmt.Errorf("worker network %s overlaps the SNAT IP pool range %s-%s", workers, r.Start, r.End)
+		}
+	}
+	return nil
+}
+
+// CheckPrivateWorkerNetwork checks that the given worker network is within one of the private address ranges
+// of RFC 1918.
+func CheckPrivateWorkerNetwork(workers string) error {
+	first, last, err := networkRange(workers)
+	if err != nil {
+		return err
+	}
+
+	for _, private := range privateNetworks {
+		privateFirst, privateLast, err := networkRange(private)
+		if err != nil {
+			return err
+		}
+		if privateFirst <= first && last <= privateLast {
+			return nil
+		}
+	}
+	return fmt.Errorf("worker network %s is not within the private networks %s of RFC 1918", workers, strings.Join(privateNetworks, ", "))
+}
+
+// networkRange returns the first and the last address of the given IPv4 network.
+func networkRange(cidr string) (uint32, uint32, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if network.IP.To4() == nil {
+		return 0, 0, fmt.Errorf("network %s is not an IPv4 network", cidr)
+	}
+	ones, bits := network.Mask.Size()
+	first := ipToUint32(network.IP)
+	return first, first | (1<<uint(bits-ones) - 1), nil
+}
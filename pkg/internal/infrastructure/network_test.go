@@ -0,0 +1,71 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Network", func() {
+	Describe("#CheckWorkerNetwork", func() {
+		snatRanges := []IPRange{{Start: net.ParseIP("10.250.0.10").To4(), End: net.ParseIP("10.250.0.20").To4()}}
+
+		It("should accept a worker network without overlaps", func() {
+			Expect(CheckWorkerNetwork("10.1.0.0/16", snatRanges)).To(Succeed())
+		})
+
+		It("should reject a worker network overlapping the SNAT IP pool", func() {
+			err := CheckWorkerNetwork("10.250.0.0/24", snatRanges)
+			Expect(err).To(MatchError("worker network 10.250.0.0/24 overlaps the SNAT IP pool range 10.250.0.10-10.250.0.20"))
+		})
+
+		It("should reject a worker network overlapping the NSX-T reserved networks", func() {
+			err := CheckWorkerNetwork("100.64.128.0/20", nil)
+			Expect(err).To(MatchError("worker network 100.64.128.0/20 overlaps the NSX-T reserved network 100.64.0.0/16"))
+
+			err = CheckWorkerNetwork("169.254.0.0/16", nil)
+			Expect(err).To(MatchError("worker network 169.254.0.0/16 overlaps the NSX-T reserved network 169.254.0.0/24"))
+		})
+
+		It("should reject invalid worker networks", func() {
+			Expect(CheckWorkerNetwork("fd00::/64", nil)).To(MatchError("network fd00::/64 is not an IPv4 network"))
+		})
+	})
+
+	Describe("#CheckPrivateWorkerNetwork", func() {
+		It("should accept worker networks within the private networks", func() {
+			Expect(CheckPrivateWorkerNetwork("10.250.0.0/16")).To(Succeed())
+			Expect(CheckPrivateWorkerNetwork("172.31.0.0/16")).To(Succeed())
+			Expect(CheckPrivateWorkerNetwork("192.168.0.0/16")).To(Succeed())
+		})
+
+		It("should reject public worker networks", func() {
+			Expect(CheckPrivateWorkerNetwork("8.8.8.0/24")).To(MatchError(
+				"worker network 8.8.8.0/24 is not within the private networks 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16 of RFC 1918"))
+		})
+
+		It("should reject worker networks only partially within the private networks", func() {
+			Expect(CheckPrivateWorkerNetwork("172.16.0.0/11")).NotTo(Succeed())
+			Expect(CheckPrivateWorkerNetwork("192.168.0.0/15")).NotTo(Succeed())
+		})
+
+		It("should reject invalid worker networks", func() {
+			Expect(CheckPrivateWorkerNetwork("fd00::/64")).To(MatchError("network fd00::/64 is not an IPv4 network"))
+		})
+	})
+})
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+)
+
+// ZonePlacementChanges compares the zone placement of a previous infrastructure status with the current one and
+// describes each change which would move new machines of a zone to other vSphere resources.
+// Zones which are only contained in the current placement are not considered as changed.
+func ZonePlacementChanges(previous, current *api.VsphereConfig) []string {
+	var changes []string
+	if previous.Folder != current.Folder {
+		changes = append(changes, fmt.Sprintf("folder changed from %q to %q", previous.Folder, current.Folder))
+	}
+
+	var zoneNames []string
+	for name := range previous.ZoneConfigs {
+		zoneNames = append(zoneNames, name)
+	}
+	sort.Strings(zoneNames)
+
+	for _, name := range zoneNames {
+		prev := previous.ZoneConfigs[name]
+		cur, ok := current.ZoneConfigs[name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("zone %q was removed", name))
+			continue
+		}
+		for _, field := range []struct {
+			name      string
+			prev, cur string
+		}{
+			{"datacenter", prev.Datacenter, cur.Datacenter},
+			{"computeCluster", prev.ComputeCluster, cur.ComputeCluster},
+			{"resourcePool", prev.ResourcePool, cur.ResourcePool},
+			{"hostSystem", prev.HostSystem, cur.HostSystem},
+			{"datastore", prev.Datastore, cur.Datastore},
+			{"datastoreCluster", prev.DatastoreCluster, cur.DatastoreCluster},
+			{"folder", prev.Folder, cur.Folder},
+		} {
+			if field.prev != field.cur {
+				changes = append(changes, fmt.Sprintf("zone %q: %s changed from %q to %q", name, field.name, field.prev, field.cur))
+			}
+		}
+	}
+	return changes
+}
+
+// RetainRemovedZones copies the zone configs of the zones which are contained in the previous placement, but not in
+// the current one, to the current placement if they are still used by one of the given worker zones. The machines of
+// these zones could not be reconciled anymore otherwise. It returns the sorted names of the retained zones.
+func RetainRemovedZones(previous, current *api.VsphereConfig, workerZones []string) []string {
+	if previous.Region != current.Region {
+		return nil
+	}
+
+	var retained []string
+	for _, name := range workerZones {
+		if _, ok := current.ZoneConfigs[name]; ok {
+			continue
+		}
+		zoneConfig, ok := previous.ZoneConfigs[name]
+		if !ok {
+			continue
+		}
+		if current.ZoneConfigs == nil {
+			current.ZoneConfigs = map[string]api.ZoneConfig{}
+		}
+		current.ZoneConfigs[name] = zoneConfig
+		retained = append(retained, name)
+	}
+	sort.Strings(retained)
+	return retained
+}
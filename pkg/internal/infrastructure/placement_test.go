@@ -0,0 +1,109 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Placement", func() {
+	Describe("#ZonePlacementChanges", func() {
+		var previous, current *api.VsphereConfig
+
+		BeforeEach(func() {
+			previous = &api.VsphereConfig{
+				Folder: "folder",
+				Region: "region",
+				ZoneConfigs: map[string]api.ZoneConfig{
+					"zone1": {Datacenter: "dc", ComputeCluster: "cc1", Datastore: "ds1"},
+					"zone2": {Datacenter: "dc", ComputeCluster: "cc2", Datastore: "ds2"},
+				},
+			}
+			current = previous.DeepCopy()
+		})
+
+		It("should not report changes for the same placement", func() {
+			Expect(ZonePlacementChanges(previous, current)).To(BeEmpty())
+		})
+
+		It("should not report new zones", func() {
+			current.ZoneConfigs["zone3"] = api.ZoneConfig{Datacenter: "dc", ComputeCluster: "cc3", Datastore: "ds3"}
+
+			Expect(ZonePlacementChanges(previous, current)).To(BeEmpty())
+		})
+
+		It("should report changed and removed zones", func() {
+			current.Folder = "other"
+			current.ZoneConfigs["zone1"] = api.ZoneConfig{Datacenter: "dc", ComputeCluster: "cc1", DatastoreCluster: "dsc"}
+			delete(current.ZoneConfigs, "zone2")
+
+			Expect(ZonePlacementChanges(previous, current)).To(Equal([]string{
+				`folder changed from "folder" to "other"`,
+				`zone "zone1": datastore changed from "ds1" to ""`,
+				`zone "zone1": datastoreCluster changed from "" to "dsc"`,
+				`zone "zone2" was removed`,
+			}))
+		})
+	})
+
+	Describe("#RetainRemovedZones", func() {
+		var previous, current *api.VsphereConfig
+
+		BeforeEach(func() {
+			previous = &api.VsphereConfig{
+				Folder: "folder",
+				Region: "region",
+				ZoneConfigs: map[string]api.ZoneConfig{
+					"zone1": {Datacenter: "dc", ComputeCluster: "cc1", Datastore: "ds1"},
+					"zone2": {Datacenter: "dc", ComputeCluster: "cc2", Datastore: "ds2"},
+					"zone3": {Datacenter: "dc", ComputeCluster: "cc3", Datastore: "ds3"},
+				},
+			}
+			current = &api.VsphereConfig{
+				Folder: "folder",
+				Region: "region",
+				ZoneConfigs: map[string]api.ZoneConfig{
+					"zone1": {Datacenter: "dc", ComputeCluster: "cc1", Datastore: "other"},
+					"zone4": {Datacenter: "dc", ComputeCluster: "cc4", Datastore: "ds4"},
+				},
+			}
+		})
+
+		It("should retain removed zones which are still used by workers", func() {
+			Expect(RetainRemovedZones(previous, current, []string{"zone3", "zone1", "zone2", "zone4"})).To(Equal([]string{"zone2", "zone3"}))
+			Expect(current.ZoneConfigs).To(Equal(map[string]api.ZoneConfig{
+				"zone1": {Datacenter: "dc", ComputeCluster: "cc1", Datastore: "other"},
+				"zone2": {Datacenter: "dc", ComputeCluster: "cc2", Datastore: "ds2"},
+				"zone3": {Datacenter: "dc", ComputeCluster: "cc3", Datastore: "ds3"},
+				"zone4": {Datacenter: "dc", ComputeCluster: "cc4", Datastore: "ds4"},
+			}))
+		})
+
+		It("should drop removed zones which are not used by workers anymore", func() {
+			Expect(RetainRemovedZones(previous, current, []string{"zone1", "zone4"})).To(BeEmpty())
+			Expect(current.ZoneConfigs).To(HaveLen(2))
+		})
+
+		It("should not retain zones of another region", func() {
+			current.Region = "other"
+
+			Expect(RetainRemovedZones(previous, current, []string{"zone2"})).To(BeEmpty())
+			Expect(current.ZoneConfigs).NotTo(HaveKey("zone2"))
+		})
+	})
+})
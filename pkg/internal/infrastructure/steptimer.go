@@ -0,0 +1,73 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StepTimer measures the durations of the steps of a reconciliation, so that slow reconciliations can be triaged
+// from the log without a metrics backend.
+type StepTimer struct {
+	now   func() time.Time
+	start time.Time
+	last  time.Time
+	steps []stepDuration
+}
+
+type stepDuration struct {
+	name     string
+	duration time.Duration
+}
+
+// NewStepTimer creates a new StepTimer starting now.
+func NewStepTimer() *StepTimer {
+	return newStepTimer(time.Now)
+}
+
+func newStepTimer(now func() time.Time) *StepTimer {
+	start := now()
+	return &StepTimer{
+		now:   now,
+		start: start,
+		last:  start,
+	}
+}
+
+// Done records the duration of the step with the given name since the previous step was done.
+func (t *StepTimer) Done(name string) {
+	now := t.now()
+	t.steps = append(t.steps, stepDuration{name: name, duration: now.Sub(t.last)})
+	t.last = now
+}
+
+// Summary returns the total duration and the durations of all steps, slowest first, as key/value pairs
+// for structured logging.
+func (t *StepTimer) Summary() []interface{} {
+	steps := make([]stepDuration, len(t.steps))
+	copy(steps, t.steps)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].duration > steps[j].duration
+	})
+
+	var durations []string
+	for _, step := range steps {
+		durations = append(durations, fmt.Sprintf("%s=%s", step.name, step.duration))
+	}
+	return []interface{}{"duration", t.last.Sub(t.start).String(), "steps", strings.Join(durations, ", ")}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StepTimer", func() {
+	It("should summarize the durations of all steps, slowest first", func() {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		timer := newStepTimer(func() time.Time { return now })
+
+		now = now.Add(2 * time.Second)
+		timer.Done("preChecks")
+		now = now.Add(100 * time.Millisecond)
+		timer.Done("render")
+		now = now.Add(time.Minute)
+		timer.Done("terraformApply")
+		now = now.Add(500 * time.Millisecond)
+		timer.Done("status")
+
+		Expect(timer.Summary()).To(Equal([]interface{}{
+			"duration", "1m2.6s",
+			"steps", "terraformApply=1m0s, preChecks=2s, status=500ms, render=100ms",
+		}))
+	})
+
+	It("should summarize no steps", func() {
+		Expect(newStepTimer(time.Now).Summary()).To(Equal([]interface{}{"duration", "0s", "steps", ""}))
+	})
+})
@@ -0,0 +1,205 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+)
+
+// redacted replaces the values of sensitive fields in a support bundle.
+const redacted = "<redacted>"
+
+// sensitiveFieldParts are parts of the names of fields whose values are redacted in a support bundle.
+var sensitiveFieldParts = []string{"password", "secret", "token", "private_key", "certificate", "_user"}
+
+// SupportBundleSection is a section of a support bundle for a single NSX-T object or list of objects.
+type SupportBundleSection struct {
+	// Path is the NSX-T API path the section was read from.
+	Path string `json:"path,omitempty"`
+	// Absent is true if the object does not exist (anymore) or its id is unknown.
+	Absent bool `json:"absent,omitempty"`
+	// Error is the error which occurred on reading the object, if any.
+	Error string `json:"error,omitempty"`
+	// Object is the redacted object read from NSX-T.
+	Object interface{} `json:"object,omitempty"`
+}
+
+// SupportBundle is a snapshot of the NSX-T objects of the infrastructure of a shoot, suitable to be attached to a
+// bug report.
+type SupportBundle struct {
+	// State are the Terraform output variables of the infrastructure.
+	State map[string]string `json:"state"`
+	// Sections are the NSX-T objects of the infrastructure by kind.
+	Sections map[string]SupportBundleSection `json:"sections"`
+}
+
+const (
+	// SupportBundleSectionLogicalRouter is the section of the tier-1 logical router.
+	SupportBundleSectionLogicalRouter = "logicalRouter"
+	// SupportBundleSectionLogicalSwitch is the section of the logical switch of the worker network.
+	SupportBundleSectionLogicalSwitch = "logicalSwitch"
+	// SupportBundleSectionLogicalPorts is the section of the logical ports of the logical switch.
+	SupportBundleSectionLogicalPorts = "logicalPorts"
+	// SupportBundleSectionDHCPServer is the section of the logical DHCP server.
+	SupportBundleSectionDHCPServer = "dhcpServer"
+	// SupportBundleSectionDHCPProfile is the section of the DHCP server profile.
+	SupportBundleSectionDHCPProfile = "dhcpProfile"
+	// SupportBundleSectionDHCPIPPool is the section of the DHCP IP pool.
+	SupportBundleSectionDHCPIPPool = "dhcpIPPool"
+	// SupportBundleSectionEdgeCluster is the section of the edge cluster.
+	SupportBundleSectionEdgeCluster = "edgeCluster"
+)
+
+// ExportSupportBundle reads the NSX-T objects referenced by the given Terraform output variables of an infrastructure
+// and the referenced edge cluster, and serializes them together with the output variables into a single
+// JSON document. Sensitive fields are redacted. Objects which do not exist are marked as absent, errors on reading
+// single objects are recorded in their section.
+func ExportSupportBundle(ctx context.Context, client *nsxt.Client, outputs map[string]string, edgeCluster EdgeClusterRef) ([]byte, error) {
+	bundle := &SupportBundle{
+		State:    outputs,
+		Sections: map[string]SupportBundleSection{},
+	}
+
+	readObject := func(section, path, id string) map[string]interface{} {
+		if id == "" {
+			bundle.Sections[section] = SupportBundleSection{Absent: true}
+			return nil
+		}
+		path = fmt.Sprintf(path, url.PathEscape(id))
+		object, err := client.GetObject(ctx, path)
+		bundle.Sections[section] = newSupportBundleSection(path, object, err)
+		return object
+	}
+
+	readObject(SupportBundleSectionLogicalRouter, "/api/v1/logical-routers/%s", outputs[TerraformOutputKeyLogicalRouterId])
+	switchID := outputs[TerraformOutputKeyLogicalSwitchId]
+	if readObject(SupportBundleSectionLogicalSwitch, "/api/v1/logical-switches/%s", switchID) != nil {
+		path := "/api/v1/logical-ports"
+		ports, err := client.ListObjects(ctx, path, url.Values{"logical_switch_id": []string{switchID}})
+		bundle.Sections[SupportBundleSectionLogicalPorts] = newSupportBundleSection(path, ports, err)
+	} else {
+		bundle.Sections[SupportBundleSectionLogicalPorts] = SupportBundleSection{Absent: true}
+	}
+
+	serverID := outputs[TerraformOutputKeyDHCPServerId]
+	var profileID string
+	if server := readObject(SupportBundleSectionDHCPServer, "/api/v1/dhcp/servers/%s", serverID); server != nil {
+		profileID, _ = server["dhcp_profile_id"].(string)
+	}
+	readObject(SupportBundleSectionDHCPProfile, "/api/v1/dhcp/server-profiles/%s", profileID)
+	if poolID := outputs[TerraformOutputKeyDHCPIPPoolId]; serverID != "" && poolID != "" {
+		readObject(SupportBundleSectionDHCPIPPool, "/api/v1/dhcp/servers/"+url.PathEscape(serverID)+"/ip-pools/%s", poolID)
+	} else {
+		bundle.Sections[SupportBundleSectionDHCPIPPool] = SupportBundleSection{Absent: true}
+	}
+
+	if cluster, err := lookupEdgeCluster(ctx, client, edgeCluster); err != nil {
+		bundle.Sections[SupportBundleSectionEdgeCluster] = newSupportBundleSection("", nil, err)
+	} else {
+		readObject(SupportBundleSectionEdgeCluster, "/api/v1/edge-clusters/%s", cluster.ID)
+	}
+
+	return json.MarshalIndent(bundle, "", "  ")
+}
+
+// terraformStateFile is the part of a Terraform state file containing the output variables, in the format of Terraform
+// 0.11 (modules) or 0.12 (top-level outputs).
+type terraformStateFile struct {
+	Modules []struct {
+		Outputs map[string]terraformOutput `json:"outputs"`
+	} `json:"modules"`
+	Outputs map[string]terraformOutput `json:"outputs"`
+}
+
+type terraformOutput struct {
+	Value interface{} `json:"value"`
+}
+
+// ParseTerraformStateOutputs returns the string output variables of the given Terraform state file.
+func ParseTerraformStateOutputs(data []byte) (map[string]string, error) {
+	state := &terraformStateFile{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("could not parse Terraform state: %v", err)
+	}
+
+	variables := state.Outputs
+	if len(state.Modules) > 0 {
+		variables = state.Modules[0].Outputs
+	}
+	outputs := make(map[string]string, len(variables))
+	for key, variable := range variables {
+		if value, ok := variable.Value.(string); ok {
+			outputs[key] = value
+		}
+	}
+	return outputs, nil
+}
+
+func newSupportBundleSection(path string, object interface{}, err error) SupportBundleSection {
+	switch {
+	case nsxt.IsNotFound(err):
+		return SupportBundleSection{Path: path, Absent: true}
+	case err != nil:
+		return SupportBundleSection{Path: path, Error: err.Error()}
+	default:
+		return SupportBundleSection{Path: path, Object: redact(object)}
+	}
+}
+
+// redact returns a copy of the given JSON value with the values of all sensitive fields replaced.
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if isSensitiveField(key) {
+				result[key] = redacted
+			} else {
+				result[key] = redact(field)
+			}
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = redact(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = redact(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func isSensitiveField(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveFieldParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SupportBundle", func() {
+	var (
+		ctx       = context.TODO()
+		server    *httptest.Server
+		client    *nsxt.Client
+		responses map[string]string
+		outputs   map[string]string
+	)
+
+	BeforeEach(func() {
+		responses = map[string]string{
+			"/api/v1/logical-routers/router-1":       `{"id": "router-1", "display_name": "router", "_create_user": "admin"}`,
+			"/api/v1/logical-switches/switch-1":      `{"id": "switch-1", "display_name": "switch"}`,
+			"/api/v1/logical-ports":                  `{"results": [{"id": "port-1", "logical_switch_id": "switch-1", "_last_modified_user": "admin"}]}`,
+			"/api/v1/dhcp/servers/server-1":          `{"id": "server-1", "dhcp_profile_id": "profile-1"}`,
+			"/api/v1/dhcp/server-profiles/profile-1": `{"id": "profile-1", "edge_cluster_id": "ec-1"}`,
+			"/api/v1/edge-clusters":                  `{"results": [{"id": "ec-1", "display_name": "edge", "members": []}]}`,
+			"/api/v1/edge-clusters/ec-1":             `{"id": "ec-1", "display_name": "edge", "credentials": {"password": "secret"}}`,
+		}
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			response, ok := responses[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		}))
+		client = nsxt.NewClient(server.URL, "user", "password", true)
+		outputs = map[string]string{
+			TerraformOutputKeyLogicalRouterId: "router-1",
+			TerraformOutputKeyLogicalSwitchId: "switch-1",
+			TerraformOutputKeyDHCPServerId:    "server-1",
+			TerraformOutputKeyDHCPIPPoolId:    "pool-1",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#ExportSupportBundle", func() {
+		It("should export all sections and redact sensitive fields", func() {
+			data, err := ExportSupportBundle(ctx, client, outputs, EdgeClusterRef{Name: "edge"})
+			Expect(err).NotTo(HaveOccurred())
+
+			bundle := &SupportBundle{}
+			Expect(json.Unmarshal(data, bundle)).To(Succeed())
+			Expect(bundle.State).To(Equal(outputs))
+			Expect(bundle.Sections).To(HaveLen(7))
+			Expect(bundle.Sections[SupportBundleSectionLogicalRouter].Object).To(Equal(map[string]interface{}{
+				"id": "router-1", "display_name": "router", "_create_user": redacted,
+			}))
+			Expect(bundle.Sections[SupportBundleSectionLogicalSwitch].Object).To(HaveKeyWithValue("id", "switch-1"))
+			Expect(bundle.Sections[SupportBundleSectionLogicalPorts].Object).To(Equal([]interface{}{
+				map[string]interface{}{"id": "port-1", "logical_switch_id": "switch-1", "_last_modified_user": redacted},
+			}))
+			Expect(bundle.Sections[SupportBundleSectionDHCPServer].Object).To(HaveKeyWithValue("id", "server-1"))
+			Expect(bundle.Sections[SupportBundleSectionDHCPProfile].Object).To(HaveKeyWithValue("id", "profile-1"))
+			Expect(bundle.Sections[SupportBundleSectionEdgeCluster].Object).To(HaveKeyWithValue("credentials",
+				map[string]interface{}{"password": redacted}))
+			Expect(string(data)).NotTo(ContainSubstring("secret"))
+		})
+
+		It("should read the edge cluster by its id if known", func() {
+			delete(responses, "/api/v1/edge-clusters")
+
+			data, err := ExportSupportBundle(ctx, client, outputs, EdgeClusterRef{ID: "ec-1", Name: "other"})
+			Expect(err).NotTo(HaveOccurred())
+
+			bundle := &SupportBundle{}
+			Expect(json.Unmarshal(data, bundle)).To(Succeed())
+			Expect(bundle.Sections[SupportBundleSectionEdgeCluster].Path).To(Equal("/api/v1/edge-clusters/ec-1"))
+			Expect(bundle.Sections[SupportBundleSectionEdgeCluster].Object).To(HaveKeyWithValue("id", "ec-1"))
+		})
+
+		It("should record an error if the edge cluster cannot be found by its name", func() {
+			data, err := ExportSupportBundle(ctx, client, outputs, EdgeClusterRef{Name: "other"})
+			Expect(err).NotTo(HaveOccurred())
+
+			bundle := &SupportBundle{}
+			Expect(json.Unmarshal(data, bundle)).To(Succeed())
+			Expect(bundle.Sections[SupportBundleSectionEdgeCluster].Error).To(ContainSubstring(`edge cluster "other" not found`))
+		})
+
+		It("should mark missing objects as absent", func() {
+			outputs[TerraformOutputKeyLogicalSwitchId] = "switch-2"
+			delete(outputs, TerraformOutputKeyDHCPServerId)
+
+			data, err := ExportSupportBundle(ctx, client, outputs, EdgeClusterRef{ID: "ec-2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			bundle := &SupportBundle{}
+			Expect(json.Unmarshal(data, bundle)).To(Succeed())
+			Expect(bundle.Sections).To(HaveLen(7))
+			Expect(bundle.Sections[SupportBundleSectionLogicalRouter].Absent).To(BeFalse())
+			Expect(bundle.Sections[SupportBundleSectionLogicalSwitch]).To(Equal(SupportBundleSection{
+				Path: "/api/v1/logical-switches/switch-2", Absent: true,
+			}))
+			for _, section := range []string{
+				SupportBundleSectionLogicalPorts,
+				SupportBundleSectionDHCPServer,
+				SupportBundleSectionDHCPProfile,
+				SupportBundleSectionDHCPIPPool,
+				SupportBundleSectionEdgeCluster,
+			} {
+				Expect(bundle.Sections[section].Absent).To(BeTrue(), section)
+			}
+		})
+	})
+
+	Describe("#ParseTerraformStateOutputs", func() {
+		It("should return the outputs of a Terraform 0.11 state", func() {
+			outputs, err := ParseTerraformStateOutputs([]byte(`{"version": 3, "modules": [{"outputs": {
+				"dhcp_server_id": {"type": "string", "value": "server-1"},
+				"nat_ip_addresses": {"type": "list", "value": ["10.0.0.1"]}}}]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal(map[string]string{TerraformOutputKeyDHCPServerId: "server-1"}))
+		})
+
+		It("should return the outputs of a Terraform 0.12 state", func() {
+			outputs, err := ParseTerraformStateOutputs([]byte(`{"version": 4, "outputs": {
+				"dhcp_server_id": {"type": "string", "value": "server-1"}}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal(map[string]string{TerraformOutputKeyDHCPServerId: "server-1"}))
+		})
+	})
+})
@@ -18,10 +18,14 @@ package infrastructure
 
 import (
 	"fmt"
+	"net"
+	"os"
 	"path/filepath"
+	"reflect"
 
 	api "github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere/helper"
+	internalhelper "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/helper"
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/vsphere"
 
 	"github.com/gardener/gardener-extensions/pkg/terraformer"
@@ -39,14 +43,48 @@ const (
 	TerraformOutputKeyLogicalRouterId = "logical_router_id"
 	// TerraformOutputKeyLogicalSwitchId is id of the logical switch
 	TerraformOutputKeyLogicalSwitchId = "logical_switch_id"
+	// TerraformOutputKeyDHCPProfileId is id of the DHCP server profile
+	TerraformOutputKeyDHCPProfileId = "dhcp_profile_id"
+	// TerraformOutputKeyDHCPServerId is id of the logical DHCP server
+	TerraformOutputKeyDHCPServerId = "dhcp_server_id"
+	// TerraformOutputKeyDHCPPortId is id of the logical port of the DHCP server on the logical switch
+	TerraformOutputKeyDHCPPortId = "dhcp_port_id"
+	// TerraformOutputKeyDHCPIPPoolId is id of the DHCP IP pool of the worker network
+	TerraformOutputKeyDHCPIPPoolId = "dhcp_ip_pool_id"
+	// TerraformOutputKeyDHCPGatewayIP is the gateway IP address handed out to the nodes by the DHCP IP pool of the worker network
+	TerraformOutputKeyDHCPGatewayIP = "dhcp_gateway_ip"
+	// TerraformOutputKeyRouterDownlinkIP is the IP address of the downlink port of the T1 router on the worker network
+	TerraformOutputKeyRouterDownlinkIP = "router_downlink_ip"
+
+	// TerraformChartOverwriteEnv is the name of the environment variable that can be set to render the
+	// Terraform configuration from an alternative chart directory instead of the embedded vsphere-infra chart.
+	TerraformChartOverwriteEnv = "TERRAFORM_CHART_OVERWRITE"
 )
 
+// ChartOptions are options for the vSphere Terraformer chart which are not part of the InfrastructureConfig.
+type ChartOptions struct {
+	// ExcludedDHCPRanges are address ranges which are not handed out by the DHCP server of the worker network.
+	ExcludedDHCPRanges []IPRange
+	// WithoutDHCP removes the DHCP server of the worker network, but keeps the network itself.
+	WithoutDHCP bool
+	// DHCPEdgeCluster is the name of the edge cluster of the DHCP server profile if it differs from the edge cluster
+	// of the region.
+	DHCPEdgeCluster string
+	// AdoptDHCPServerRenames keeps the display name of the DHCP server of the worker network if it was changed
+	// externally instead of restoring it.
+	AdoptDHCPServerRenames bool
+	// GardenID is the identity of the Gardener landscape. If set, all NSX-T objects are tagged with it to distinguish
+	// the objects of landscapes sharing an NSX-T manager.
+	GardenID string
+}
+
 // ComputeTerraformerChartValues computes the values for the vSphere Terraformer chart.
 func ComputeTerraformerChartValues(
 	infra *extensionsv1alpha1.Infrastructure,
 	config *api.InfrastructureConfig,
 	cloudProfileConfig *api.CloudProfileConfig,
-	networking corev1beta1.Networking,
+	shoot *corev1beta1.Shoot,
+	opts ChartOptions,
 ) (map[string]interface{}, error) {
 	region := helper.FindRegion(infra.Spec.Region, cloudProfileConfig)
 	if region == nil {
@@ -55,28 +93,213 @@ func ComputeTerraformerChartValues(
 	if len(region.Zones) == 0 {
 		return nil, fmt.Errorf("region %q has no zones in cloud profile", infra.Spec.Region)
 	}
+	dnsServers, err := DNSServers(cloudProfileConfig, region, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// The worker network is immutable, so it is only checked before the infrastructure is created.
+	if infra.Status.ProviderStatus == nil {
+		if err := CheckWorkerNetwork(*shoot.Spec.Networking.Nodes, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	networks := map[string]interface{}{
+		"worker": *shoot.Spec.Networking.Nodes,
+	}
+	if config.WorkerSegmentMTU != nil {
+		if max := region.MaxWorkerSegmentMTU; max != nil && *config.WorkerSegmentMTU > *max {
+			return nil, fmt.Errorf("worker segment MTU %d exceeds the MTU %d of the logical switches of region %q", *config.WorkerSegmentMTU, *max, region.Name)
+		}
+		networks["workerMTU"] = *config.WorkerSegmentMTU
+	}
+
+	dhcp := map[string]interface{}{}
+	if config.DHCPDomainName != nil {
+		dhcp["domainName"] = *config.DHCPDomainName
+	}
+	if searchDomains := dhcpSearchDomains(config.DHCPDomainName, config.DHCPSearchDomains); len(searchDomains) > 0 {
+		dhcp["searchDomains"] = searchDomains
+	}
+	if len(config.DHCPStaticRoutes) > 0 {
+		var routes []interface{}
+		for _, route := range config.DHCPStaticRoutes {
+			routes = append(routes, map[string]interface{}{
+				"destination": route.Destination,
+				"nextHop":     route.NextHop,
+			})
+		}
+		dhcp["staticRoutes"] = routes
+	}
+	if len(config.DHCPOptions) > 0 {
+		var options []interface{}
+		for _, option := range config.DHCPOptions {
+			options = append(options, map[string]interface{}{
+				"code":   option.Code,
+				"values": option.Values,
+			})
+		}
+		dhcp["options"] = options
+	}
+	dhcpRanges, err := parseAddressRanges(config.DHCPRanges)
+	if err != nil {
+		return nil, err
+	}
+	excludedRanges, err := parseAddressRanges(config.DHCPExcludedRanges)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRangesWithinNetwork(*shoot.Spec.Networking.Nodes, excludedRanges); err != nil {
+		return nil, err
+	}
+	excludedRanges = append(excludedRanges, opts.ExcludedDHCPRanges...)
+	if len(dhcpRanges) > 0 || len(excludedRanges) > 0 || config.DHCPServerIP != nil || config.DHCPClientGateway != nil {
+		ranges, changed, err := computeDHCPRanges(*shoot.Spec.Networking.Nodes, dhcpRanges, excludedRanges)
+		if err != nil {
+			return nil, err
+		}
+		if config.DHCPServerIP != nil {
+			if err := checkDHCPServerIP(*shoot.Spec.Networking.Nodes, *config.DHCPServerIP, ranges); err != nil {
+				return nil, err
+			}
+			dhcp["serverIP"] = *config.DHCPServerIP
+		}
+		if config.DHCPClientGateway != nil {
+			if err := checkDHCPClientGateway(*shoot.Spec.Networking.Nodes, *config.DHCPClientGateway, config.DHCPServerIP, ranges); err != nil {
+				return nil, err
+			}
+			dhcp["clientGateway"] = *config.DHCPClientGateway
+		}
+		if changed {
+			var values []interface{}
+			for _, r := range ranges {
+				values = append(values, map[string]interface{}{
+					"start": r.Start.String(),
+					"end":   r.End.String(),
+				})
+			}
+			dhcp["ranges"] = values
+		}
+	}
+
+	if opts.WithoutDHCP {
+		dhcp["disabled"] = true
+	}
+	if opts.DHCPEdgeCluster != "" {
+		dhcp["edgeCluster"] = opts.DHCPEdgeCluster
+	}
+	if opts.AdoptDHCPServerRenames {
+		dhcp["adoptRenames"] = true
+	}
+
+	var sshPublicKey string
+	if len(infra.Spec.SSHPublicKey) > 0 {
+		if sshPublicKey, err = internalhelper.NormalizeSSHPublicKey(string(infra.Spec.SSHPublicKey)); err != nil {
+			return nil, err
+		}
+	}
+
+	fullClusterName, err := helper.FullClusterName(cloudProfileConfig, infra.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	nsxt := map[string]interface{}{
+		"host":               region.NSXTHost,
+		"insecure":           region.NSXTInsecureSSL,
+		"transportZone":      region.TransportZone,
+		"logicalTier0Router": region.LogicalTier0Router,
+		"edgeCluster":        region.EdgeCluster,
+		"snatIpPool":         region.SNATIPPool,
+		"namePrefix":         cloudProfileConfig.NamePrefix,
+		"fullClusterName":    fullClusterName,
+		"dnsServers":         dnsServers,
+	}
+	if region.EdgeClusterID != nil && *region.EdgeClusterID != "" {
+		nsxt["edgeClusterID"] = *region.EdgeClusterID
+	}
+	if region.SwitchReplicationMode != nil {
+		nsxt["switchReplicationMode"] = *region.SwitchReplicationMode
+	}
+	if len(region.SwitchingProfiles) > 0 {
+		nsxt["switchingProfiles"] = region.SwitchingProfiles
+	}
+
+	values := map[string]interface{}{
+		"nsxt":         nsxt,
+		"sshPublicKey": sshPublicKey,
+		"clusterName":  infra.Namespace,
+		"shootUID":     string(shoot.UID),
+		"gardenID":     opts.GardenID,
+		"networks":     networks,
+		"dhcp":         dhcp,
+	}
+	if len(config.NSXTTags) > 0 {
+		var tags []interface{}
+		for _, tag := range config.NSXTTags {
+			tags = append(tags, map[string]interface{}{
+				"scope": tag.Scope,
+				"tag":   tag.Tag,
+			})
+		}
+		values["tags"] = tags
+	}
+	return values, nil
+}
+
+// DNSServers returns the DNS servers handed out by the DHCP server of the shoot's worker network. These are the DNS
+// servers of the zones of the shoot's workers if set, otherwise the ones of the region or of the cloud profile.
+// They must not be within the services network of the shoot, as the cluster DNS forwards to them.
+func DNSServers(cloudProfileConfig *api.CloudProfileConfig, region *api.RegionSpec, shoot *corev1beta1.Shoot) ([]string, error) {
 	dnsServers := cloudProfileConfig.DNSServers
 	if len(region.DNSServers) > 0 {
 		dnsServers = region.DNSServers
 	}
+	if zoneDNSServers := findZoneDNSServers(region, shoot); len(zoneDNSServers) > 0 {
+		dnsServers = zoneDNSServers
+	}
+	dnsServers, err := dhcpDNSServers(dnsServers)
+	if err != nil {
+		return nil, err
+	}
+	if services := shoot.Spec.Networking.Services; services != nil {
+		_, servicesNetwork, err := net.ParseCIDR(*services)
+		if err != nil {
+			return nil, err
+		}
+		for _, dnsServer := range dnsServers {
+			if servicesNetwork.Contains(net.ParseIP(dnsServer)) {
+				return nil, fmt.Errorf("DNS server %s must not be within the services network %s of the shoot, as the cluster DNS would resolve in a loop", dnsServer, *services)
+			}
+		}
+	}
+	return dnsServers, nil
+}
 
-	return map[string]interface{}{
-		"nsxt": map[string]interface{}{
-			"host":               region.NSXTHost,
-			"insecure":           region.NSXTInsecureSSL,
-			"transportZone":      region.TransportZone,
-			"logicalTier0Router": region.LogicalTier0Router,
-			"edgeCluster":        region.EdgeCluster,
-			"snatIpPool":         region.SNATIPPool,
-			"namePrefix":         cloudProfileConfig.NamePrefix,
-			"dnsServers":         dnsServers,
-		},
-		"sshPublicKey": string(infra.Spec.SSHPublicKey),
-		"clusterName":  infra.Namespace,
-		"networks": map[string]interface{}{
-			"worker": *networking.Nodes,
-		},
-	}, nil
+// findZoneDNSServers returns the DNS servers of the zones of the shoot's workers. As all zones share the DHCP server
+// of the worker network, it returns nil unless all these zones have the same DNS servers.
+func findZoneDNSServers(region *api.RegionSpec, shoot *corev1beta1.Shoot) []string {
+	var dnsServers []string
+	found := false
+	for _, worker := range shoot.Spec.Provider.Workers {
+		for _, zoneName := range worker.Zones {
+			var zoneDNSServers []string
+			for _, zone := range region.Zones {
+				if zone.Name == zoneName {
+					zoneDNSServers = zone.DNSServers
+				}
+			}
+			if len(zoneDNSServers) == 0 {
+				return nil
+			}
+			if found && !reflect.DeepEqual(dnsServers, zoneDNSServers) {
+				return nil
+			}
+			dnsServers, found = zoneDNSServers, true
+		}
+	}
+	return dnsServers
 }
 
 // RenderTerraformerChart renders the vsphere-infra chart with the given values.
@@ -85,14 +308,15 @@ func RenderTerraformerChart(
 	infra *extensionsv1alpha1.Infrastructure,
 	config *api.InfrastructureConfig,
 	cloudProfileConfig *api.CloudProfileConfig,
-	networking corev1beta1.Networking,
+	shoot *corev1beta1.Shoot,
+	opts ChartOptions,
 ) (*TerraformFiles, error) {
-	values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, networking)
+	values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	release, err := renderer.Render(filepath.Join(vsphere.InternalChartsPath, "vsphere-infra"), "vsphere-infra", infra.Namespace, values)
+	release, err := renderer.Render(terraformChartPath(), "vsphere-infra", infra.Namespace, values)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +328,37 @@ func RenderTerraformerChart(
 	}, nil
 }
 
+// RenderTerraformerTFVars renders only the terraform.tfvars file of the vsphere-infra chart with the given values,
+// e.g. to compare the variables of the Terraform configuration of different infrastructure configs.
+func RenderTerraformerTFVars(
+	renderer chartrenderer.Interface,
+	infra *extensionsv1alpha1.Infrastructure,
+	config *api.InfrastructureConfig,
+	cloudProfileConfig *api.CloudProfileConfig,
+	shoot *corev1beta1.Shoot,
+	opts ChartOptions,
+) ([]byte, error) {
+	values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := renderer.Render(terraformChartPath(), "vsphere-infra", infra.Namespace, values)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(release.FileContent("terraform.tfvars")), nil
+}
+
+// terraformChartPath returns the path of the chart used to render the Terraform configuration.
+// It can be overwritten with the TerraformChartOverwriteEnv environment variable, e.g. for testing patched charts.
+func terraformChartPath() string {
+	if path := os.Getenv(TerraformChartOverwriteEnv); path != "" {
+		return path
+	}
+	return filepath.Join(vsphere.InternalChartsPath, "vsphere-infra")
+}
+
 // TerraformFiles are the files that have been rendered from the infrastructure chart.
 type TerraformFiles struct {
 	Main      string
@@ -140,6 +395,41 @@ func extractTerraformState(tf terraformer.Terraformer) (*terraformState, error)
 	return state, nil
 }
 
+// CheckDHCPGateway checks that the gateway handed out to the nodes by the DHCP IP pool of the worker network is the
+// given client gateway of the InfrastructureConfig if set, and the IP address of the downlink port of the T1 router on
+// the worker network otherwise, as the nodes cannot reach any other network without a gateway routing their traffic.
+// It succeeds if the Terraform state has no DHCP server or was created before these outputs were added.
+func CheckDHCPGateway(tf terraformer.Terraformer, clientGateway *string) error {
+	vars, err := tf.GetStateOutputVariables(TerraformOutputKeyDHCPGatewayIP, TerraformOutputKeyRouterDownlinkIP)
+	if err != nil {
+		if terraformer.IsVariablesNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	gateway, downlink := vars[TerraformOutputKeyDHCPGatewayIP], vars[TerraformOutputKeyRouterDownlinkIP]
+	if gateway == "" || downlink == "" {
+		return nil
+	}
+	// the downlink IP address has the prefix length of the worker network
+	downlinkIP := downlink
+	if ip, _, err := net.ParseCIDR(downlink); err == nil {
+		downlinkIP = ip.String()
+	}
+	gatewayIP := net.ParseIP(gateway)
+	if clientGateway != nil {
+		if gatewayIP == nil || !gatewayIP.Equal(net.ParseIP(*clientGateway)) {
+			return fmt.Errorf("DHCP gateway %s differs from the configured client gateway %s", gateway, *clientGateway)
+		}
+		return nil
+	}
+	if gatewayIP == nil || gatewayIP.String() != downlinkIP {
+		return fmt.Errorf("DHCP gateway %s differs from the downlink IP address %s of the T1 router", gateway, downlink)
+	}
+	return nil
+}
+
 // ComputeStatus computes the status based on the Terraformer and the given InfrastructureConfig.
 func ComputeStatus(tf terraformer.Terraformer, cloudProfileConfig *api.CloudProfileConfig, regionName string) (*api.InfrastructureStatus, error) {
 	state, err := extractTerraformState(tf)
@@ -177,9 +467,15 @@ func ComputeStatus(tf terraformer.Terraformer, cloudProfileConfig *api.CloudProf
 			HostSystem:       safe(z.HostSystem),
 			Datastore:        safe(datastore),
 			DatastoreCluster: safe(datastoreCluster),
+			Folder:           safe(z.Folder),
 		}
 	}
 
+	folder := cloudProfileConfig.Folder
+	if region.Folder != nil {
+		folder = *region.Folder
+	}
+
 	status := &api.InfrastructureStatus{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: api.SchemeGroupVersion.String(),
@@ -189,7 +485,7 @@ func ComputeStatus(tf terraformer.Terraformer, cloudProfileConfig *api.CloudProf
 		LogicalRouterId: state.LogicalRouterId,
 		LogicalSwitchId: state.LogicalSwitchId,
 		VsphereConfig: api.VsphereConfig{
-			Folder:      cloudProfileConfig.Folder,
+			Folder:      folder,
 			Region:      region.Name,
 			ZoneConfigs: zoneConfigs,
 		},
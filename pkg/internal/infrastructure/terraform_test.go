@@ -17,16 +17,28 @@
 package infrastructure
 
 import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
 	"github.com/gardener/gardener-extension-provider-vsphere/pkg/apis/vsphere"
+	"github.com/gardener/gardener-extensions/pkg/terraformer"
 	corev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/chartrenderer"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 )
 
 var _ = Describe("Terraform", func() {
@@ -34,9 +46,9 @@ var _ = Describe("Terraform", func() {
 		infra              *extensionsv1alpha1.Infrastructure
 		cloudProfileConfig *vsphere.CloudProfileConfig
 		config             *vsphere.InfrastructureConfig
-		networking         corev1beta1.Networking
+		shoot              *corev1beta1.Shoot
 
-		dnsServers = []string{"a", "b"}
+		dnsServers = []string{"10.10.10.11", "10.10.10.12"}
 	)
 
 	BeforeEach(func() {
@@ -61,8 +73,17 @@ var _ = Describe("Terraform", func() {
 		}
 
 		cidr := "10.1.0.0/16"
-		networking = corev1beta1.Networking{
-			Nodes: &cidr,
+		shoot = &corev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "garden-dev",
+				Name:      "bar",
+				UID:       "3ab9f8c2-2b4c-4b5e-9d0e-1f2a3b4c5d6e",
+			},
+			Spec: corev1beta1.ShootSpec{
+				Networking: corev1beta1.Networking{
+					Nodes: &cidr,
+				},
+			},
 		}
 
 		dc := "scc01-DC"
@@ -119,7 +140,7 @@ var _ = Describe("Terraform", func() {
 
 	Describe("#ComputeTerraformerChartValues", func() {
 		It("should correctly compute the terraformer chart values", func() {
-			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, networking)
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
 			Expect(err).To(BeNil())
 
 			Expect(values).To(Equal(map[string]interface{}{
@@ -131,14 +152,748 @@ var _ = Describe("Terraform", func() {
 					"edgeCluster":        "edgecluster",
 					"snatIpPool":         "snatIpPool",
 					"namePrefix":         "nameprefix",
+					"fullClusterName":    "nameprefix_" + infra.Namespace,
 					"dnsServers":         dnsServers,
 				},
 				"sshPublicKey": string(infra.Spec.SSHPublicKey),
 				"clusterName":  infra.Namespace,
+				"shootUID":     "3ab9f8c2-2b4c-4b5e-9d0e-1f2a3b4c5d6e",
+				"gardenID":     "",
 				"networks": map[string]interface{}{
-					"worker": *networking.Nodes,
+					"worker": *shoot.Spec.Networking.Nodes,
+				},
+				"dhcp": map[string]interface{}{},
+			}))
+		})
+
+		It("should reject a new infrastructure with a worker network overlapping the NSX-T reserved networks", func() {
+			cidr := "100.64.128.0/20"
+			shoot.Spec.Networking.Nodes = &cidr
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(MatchError("worker network 100.64.128.0/20 overlaps the NSX-T reserved network 100.64.0.0/16"))
+		})
+
+		It("should not check the worker network of an existing infrastructure", func() {
+			cidr := "100.64.128.0/20"
+			shoot.Spec.Networking.Nodes = &cidr
+			infra.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{}`)}
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("DNS servers of zones", func() {
+			BeforeEach(func() {
+				cloudProfileConfig.Regions[0].DNSServers = []string{"10.10.10.13"}
+				cloudProfileConfig.Regions[0].Zones = []vsphere.ZoneSpec{
+					{Name: "zone1", DNSServers: []string{"10.10.11.11"}},
+					{Name: "zone2", DNSServers: []string{"10.10.11.11"}},
+					{Name: "zone3", DNSServers: []string{"10.10.13.11"}},
+					{Name: "zone4"},
+				}
+			})
+
+			DescribeTable("should pass the DNS servers of the zones if all zones of the workers agree",
+				func(zones [][]string, expected []string) {
+					for _, workerZones := range zones {
+						shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, corev1beta1.Worker{Zones: workerZones})
+					}
+
+					values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+					Expect(err).To(BeNil())
+
+					Expect(values["nsxt"]).To(HaveKeyWithValue("dnsServers", expected))
+				},
+				Entry("single zone", [][]string{{"zone1"}}, []string{"10.10.11.11"}),
+				Entry("zones with the same DNS servers", [][]string{{"zone1"}, {"zone1", "zone2"}}, []string{"10.10.11.11"}),
+				Entry("zones with different DNS servers", [][]string{{"zone1", "zone3"}}, []string{"10.10.10.13"}),
+				Entry("zone without DNS servers", [][]string{{"zone1"}, {"zone4"}}, []string{"10.10.10.13"}),
+				Entry("unknown zone", [][]string{{"zone5"}}, []string{"10.10.10.13"}),
+				Entry("no workers", nil, []string{"10.10.10.13"}),
+			)
+		})
+
+		Context("DNS servers in the services network", func() {
+			BeforeEach(func() {
+				services := "100.64.0.0/13"
+				shoot.Spec.Networking.Services = &services
+			})
+
+			It("should pass DNS servers outside of the services network", func() {
+				values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(values["nsxt"]).To(HaveKeyWithValue("dnsServers", dnsServers))
+			})
+
+			It("should fail for DNS servers within the services network", func() {
+				cloudProfileConfig.Regions[0].DNSServers = []string{"10.10.10.13", "100.64.0.10"}
+
+				_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).To(MatchError("DNS server 100.64.0.10 must not be within the services network 100.64.0.0/13 of the shoot, as the cluster DNS would resolve in a loop"))
+			})
+		})
+
+		It("should pass the NSX-T tags if set", func() {
+			config.NSXTTags = []vsphere.NSXTTag{{Scope: "cost-center", Tag: "1234"}}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(values["tags"]).To(Equal([]interface{}{
+				map[string]interface{}{"scope": "cost-center", "tag": "1234"},
+			}))
+		})
+
+		It("should pass the worker segment MTU if set", func() {
+			mtu := 1400
+			config.WorkerSegmentMTU = &mtu
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(values["networks"]).To(Equal(map[string]interface{}{
+				"worker":    *shoot.Spec.Networking.Nodes,
+				"workerMTU": 1400,
+			}))
+		})
+
+		It("should fail for a worker segment MTU exceeding the MTU of the logical switches", func() {
+			mtu, max := 8900, 1500
+			config.WorkerSegmentMTU = &mtu
+			cloudProfileConfig.Regions[0].MaxWorkerSegmentMTU = &max
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(MatchError(`worker segment MTU 8900 exceeds the MTU 1500 of the logical switches of region "testregion"`))
+
+			max = 8900
+			_, err = ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+		})
+
+		It("should fail for configured excluded ranges outside of the worker network", func() {
+			config.DHCPExcludedRanges = []vsphere.AddressRange{{Start: "10.2.0.100", End: "10.2.0.109"}}
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(MatchError("DHCP excluded range 10.2.0.100-10.2.0.109 must be within worker network 10.1.0.0/16"))
+		})
+
+		It("should not pass DHCP ranges if the excluded ranges are outside of the worker network", func() {
+			excluded := []IPRange{{Start: net.ParseIP("10.2.0.1"), End: net.ParseIP("10.2.0.10")}}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{ExcludedDHCPRanges: excluded})
+			Expect(err).To(BeNil())
+
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{}))
+		})
+
+		It("should pass DHCP ranges without the excluded ranges", func() {
+			excluded := []IPRange{{Start: net.ParseIP("10.1.0.100"), End: net.ParseIP("10.1.0.100")}}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{ExcludedDHCPRanges: excluded})
+			Expect(err).To(BeNil())
+
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"ranges": []interface{}{
+					map[string]interface{}{"start": "10.1.0.10", "end": "10.1.0.99"},
+					map[string]interface{}{"start": "10.1.0.101", "end": "10.1.255.255"},
+				},
+			}))
+		})
+
+		It("should pass the DHCP static routes if set", func() {
+			config.DHCPStaticRoutes = []vsphere.DHCPStaticRoute{
+				{Destination: "10.10.0.0/16", NextHop: "10.1.0.254"},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"staticRoutes": []interface{}{
+					map[string]interface{}{"destination": "10.10.0.0/16", "nextHop": "10.1.0.254"},
+				},
+			}))
+		})
+
+		It("should pass the configured DHCP ranges", func() {
+			config.DHCPRanges = []vsphere.AddressRange{{Start: "10.1.0.10", End: "10.1.0.200"}}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"ranges": []interface{}{
+					map[string]interface{}{"start": "10.1.0.10", "end": "10.1.0.200"},
+				},
+			}))
+
+			config.DHCPRanges = nil
+			config.DHCPExcludedRanges = []vsphere.AddressRange{{Start: "10.1.0.0", End: "10.1.0.99"}}
+
+			values, err = ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"ranges": []interface{}{
+					map[string]interface{}{"start": "10.1.0.100", "end": "10.1.255.255"},
+				},
+			}))
+		})
+
+		It("should fail for DHCP ranges outside of the worker network", func() {
+			config.DHCPRanges = []vsphere.AddressRange{{Start: "10.2.0.10", End: "10.2.0.200"}}
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should pass the DHCP server IP if set", func() {
+			ip := "10.1.0.5"
+			config.DHCPServerIP = &ip
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"serverIP": "10.1.0.5",
+			}))
+		})
+
+		It("should pass the DHCP client gateway if set", func() {
+			ip := "10.1.0.6"
+			config.DHCPClientGateway = &ip
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"clientGateway": "10.1.0.6",
+			}))
+		})
+
+		It("should fail for a DHCP client gateway equal to the DHCP server IP", func() {
+			ip := "10.1.0.2"
+			config.DHCPClientGateway = &ip
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for a DHCP server IP within the DHCP range", func() {
+			ip := "10.1.0.10"
+			config.DHCPServerIP = &ip
+
+			_, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should disable the DHCP server if requested", func() {
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{WithoutDHCP: true})
+			Expect(err).To(BeNil())
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"disabled": true,
+			}))
+		})
+
+		It("should pass the DHCP domain name and search domains if set", func() {
+			domain := "cluster.example.com"
+			config.DHCPDomainName = &domain
+			config.DHCPSearchDomains = []string{"example.com", "svc.example.com"}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"domainName":    "cluster.example.com",
+				"searchDomains": []string{"cluster.example.com", "example.com", "svc.example.com"},
+			}))
+		})
+
+		It("should pass the DHCP options if set", func() {
+			config.DHCPOptions = []vsphere.DHCPOption{{Code: 43, Values: []string{"01:04:0a:fa:00:05"}}}
+
+			values, err := ComputeTerraformerChartValues(infra, config, cloudProfileConfig, shoot, ChartOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(values["dhcp"]).To(Equal(map[string]interface{}{
+				"options": []interface{}{
+					map[string]interface{}{"code": 43, "values": []string{"01:04:0a:fa:00:05"}},
 				},
 			}))
 		})
 	})
+
+	Describe("#RenderTerraformerChart", func() {
+		var (
+			server   *httptest.Server
+			renderer chartrenderer.Interface
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"major": "1", "minor": "16", "gitVersion": "v1.16.0"}`))
+			}))
+
+			var err error
+			renderer, err = chartrenderer.NewForConfig(&rest.Config{Host: server.URL})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.Unsetenv(TerraformChartOverwriteEnv)).To(Succeed())
+		})
+
+		Context("with the vsphere-infra chart", func() {
+			BeforeEach(func() {
+				Expect(os.Setenv(TerraformChartOverwriteEnv, filepath.Join("..", "..", "..", "charts", "internal", "vsphere-infra"))).To(Succeed())
+			})
+
+			It("should tag all NSX-T objects with the shoot UID", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`default = "3ab9f8c2-2b4c-4b5e-9d0e-1f2a3b4c5d6e"`))
+				Expect(strings.Count(files.Main, `scope = "shoot-uid"`)).To(Equal(strings.Count(files.Main, `scope = "shoot"`)))
+			})
+
+			It("should add the configured tags to all NSX-T objects", func() {
+				config.NSXTTags = []vsphere.NSXTTag{
+					{Scope: "cost-center", Tag: "1234"},
+					{Scope: "classification", Tag: "internal"},
+				}
+
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				objects := strings.Count(files.Main, `scope = "shoot"`)
+				Expect(strings.Count(files.Main, `scope = "cost-center"
+    tag   = "1234"`)).To(Equal(objects))
+				Expect(strings.Count(files.Main, `scope = "classification"
+    tag   = "internal"`)).To(Equal(objects))
+				Expect(strings.Count(files.Main, `scope = "shoot-uid"`)).To(Equal(objects))
+			})
+
+			It("should tag all NSX-T objects with the garden identity", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).NotTo(ContainSubstring("nsx_tag_garden"))
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{GardenID: "landscape-dev"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`variable "nsx_tag_garden" {
+    default = "landscape-dev"
+}`))
+				Expect(strings.Count(files.Main, `scope = "garden"`)).To(Equal(strings.Count(files.Main, `scope = "shoot"`)))
+			})
+
+			It("should restore the display name of the DHCP server by default", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).NotTo(ContainSubstring("ignore_changes"))
+			})
+
+			It("should adopt external renames of the DHCP server", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{AdoptDHCPServerRenames: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strings.Count(files.Main, `ignore_changes = ["display_name"]`)).To(Equal(1))
+				lifecycle := strings.Index(files.Main, "lifecycle {")
+				Expect(lifecycle).To(BeNumerically(">", strings.Index(files.Main, `resource "nsxt_logical_dhcp_server" "dhcpserver"`)))
+				Expect(lifecycle).To(BeNumerically("<", strings.Index(files.Main, `resource "nsxt_logical_dhcp_port" "dhcpserver"`)))
+			})
+
+			It("should bind the DHCP server profile to the selected edge cluster", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).NotTo(ContainSubstring(`data "nsxt_edge_cluster" "dhcp"`))
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{DHCPEdgeCluster: "standby"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`data "nsxt_edge_cluster" "dhcp" {
+    display_name = "standby"
+}`))
+				Expect(strings.Count(files.Main, `edge_cluster_id = "${data.nsxt_edge_cluster.dhcp.id}"`)).To(Equal(1))
+				Expect(strings.Count(files.Main, `edge_cluster_id             = "${data.nsxt_edge_cluster.cluster.id}"`)).To(Equal(1))
+			})
+
+			It("should render the same terraform.tfvars as the full chart", func() {
+				config.NSXTTags = []vsphere.NSXTTag{{Scope: "team", Tag: "network"}}
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{GardenID: "landscape-dev"})
+				Expect(err).NotTo(HaveOccurred())
+
+				tfvars, err := RenderTerraformerTFVars(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{GardenID: "landscape-dev"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tfvars).NotTo(BeEmpty())
+				Expect(tfvars).To(Equal(files.TFVars))
+			})
+
+			It("should set the replication mode of the logical switch", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`replication_mode = "MTEP"`))
+
+				mode := "SOURCE"
+				cloudProfileConfig.Regions[0].SwitchReplicationMode = &mode
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`replication_mode = "SOURCE"`))
+				Expect(files.Main).NotTo(ContainSubstring(`replication_mode = "MTEP"`))
+			})
+
+			It("should bind the switching profiles to the logical switch", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).NotTo(ContainSubstring("switching_profile"))
+
+				cloudProfileConfig.Regions[0].SwitchingProfiles = []string{"qos", "mac-discovery"}
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`data "nsxt_switching_profile" "switch_0" {
+    display_name = "qos"
+}`))
+				Expect(files.Main).To(ContainSubstring(`data "nsxt_switching_profile" "switch_1" {
+    display_name = "mac-discovery"
+}`))
+				Expect(files.Main).To(ContainSubstring(`  switching_profile_id {
+    key   = "${data.nsxt_switching_profile.switch_1.resource_type}"
+    value = "${data.nsxt_switching_profile.switch_1.id}"
+  }`))
+				Expect(strings.Count(files.Main, "switching_profile_id {")).To(Equal(2))
+
+				cloudProfileConfig.Regions[0].SwitchingProfiles = []string{"other-qos"}
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`display_name = "other-qos"`))
+				Expect(strings.Count(files.Main, "switching_profile_id {")).To(Equal(1))
+			})
+
+			It("should look up the edge cluster by id if set", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`data "nsxt_edge_cluster" "cluster" {
+    display_name = "edgecluster"
+}`))
+
+				ecID := "ec-1"
+				cloudProfileConfig.Regions[0].EdgeClusterID = &ecID
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`data "nsxt_edge_cluster" "cluster" {
+    id = "ec-1"
+}`))
+			})
+
+			It("should not add the shoot UID tag if the UID is unknown", func() {
+				shoot.UID = ""
+
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).NotTo(ContainSubstring("shoot-uid"))
+				Expect(files.Main).NotTo(ContainSubstring("nsx_tag_shoot_uid"))
+			})
+
+			It("should hand out the DHCP static routes", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).NotTo(ContainSubstring("dhcp_option_121"))
+
+				config.DHCPStaticRoutes = []vsphere.DHCPStaticRoute{
+					{Destination: "10.10.0.0/16", NextHop: "10.1.0.254"},
+					{Destination: "10.20.0.0/16", NextHop: "10.1.0.253"},
+				}
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`dhcp_option_121 {
+    network  = "10.10.0.0/16"
+    next_hop = "10.1.0.254"
+  }
+
+  dhcp_option_121 {
+    network  = "10.20.0.0/16"
+    next_hop = "10.1.0.253"
+  }`))
+			})
+
+			It("should use the configured DHCP server IP", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`dhcp_server_ip   = "${cidrhost(var.nsx_networks_worker, 2)}${var.nsx_networks_worker_suffix}"`))
+
+				ip := "10.1.0.5"
+				config.DHCPServerIP = &ip
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`dhcp_server_ip   = "10.1.0.5${var.nsx_networks_worker_suffix}"`))
+			})
+
+			It("should use the configured DHCP client gateway", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`gateway_ip             = "${nsxt_logical_dhcp_server.dhcpserver.gateway_ip}"`))
+
+				ip := "10.1.0.6"
+				config.DHCPClientGateway = &ip
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files.Main).To(ContainSubstring(`gateway_ip             = "10.1.0.6"`))
+				Expect(files.Main).To(ContainSubstring(`value = "${nsxt_dhcp_server_ip_pool.dhcp_pool.gateway_ip}"`))
+				Expect(files.Main).To(ContainSubstring(`gateway_ip       = "${cidrhost(var.nsx_networks_worker, 1)}"`))
+			})
+
+			It("should remove the DHCP server but keep the network", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{WithoutDHCP: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`resource "nsxt_logical_switch" "switch"`))
+				Expect(files.Main).To(ContainSubstring(`resource "nsxt_logical_tier1_router" "router"`))
+				Expect(files.Main).To(ContainSubstring(`output "logical_switch_id"`))
+				Expect(files.Main).NotTo(ContainSubstring("dhcp"))
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`resource "nsxt_logical_dhcp_server" "dhcpserver"`))
+				Expect(files.Main).To(ContainSubstring(`output "dhcp_ip_pool_id"`))
+			})
+
+			It("should hand out the default DHCP range", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strings.Count(files.Main, "ip_range {")).To(Equal(1))
+				Expect(files.Main).To(ContainSubstring(`start = "${cidrhost(var.nsx_networks_worker, 10)}"`))
+			})
+
+			It("should hand out the DHCP ranges without the excluded ranges", func() {
+				excluded := []IPRange{{Start: net.ParseIP("10.1.0.100"), End: net.ParseIP("10.1.0.100")}}
+
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{ExcludedDHCPRanges: excluded})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`ip_range {
+    start = "10.1.0.10"
+    end   = "10.1.0.99"
+  }
+
+  ip_range {
+    start = "10.1.0.101"
+    end   = "10.1.255.255"
+  }`))
+				Expect(files.Main).NotTo(ContainSubstring("cidrhost(var.nsx_networks_worker, 10)"))
+			})
+
+			It("should not hand out the MTU if it is not set", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).NotTo(ContainSubstring("interface MTU"))
+			})
+
+			It("should hand out the worker segment MTU", func() {
+				mtu := 8900
+				config.WorkerSegmentMTU = &mtu
+
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`code   = "26" # 26 = interface MTU
+    values = ["8900"]`))
+			})
+
+			It("should not hand out DHCP domain options if they are not set", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).NotTo(ContainSubstring("domain_name"))
+				Expect(files.Main).NotTo(ContainSubstring("domain search list"))
+			})
+
+			It("should hand out the DHCP domain name and search domains", func() {
+				domain := "cluster.example.com"
+				config.DHCPDomainName = &domain
+				config.DHCPSearchDomains = []string{"example.com"}
+
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`domain_name      = "cluster.example.com"`))
+				Expect(files.Main).To(ContainSubstring(`code   = "119" # 119 = domain search list
+    values = ["cluster.example.com", "example.com"]`))
+
+				config.DHCPSearchDomains = []string{"example.com", "svc.example.com"}
+
+				files, err = RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`values = ["cluster.example.com", "example.com", "svc.example.com"]`))
+			})
+
+			It("should hand out the DHCP options", func() {
+				config.DHCPOptions = []vsphere.DHCPOption{
+					{Code: 43, Values: []string{"01:04:0a:fa:00:05"}},
+					{Code: 42, Values: []string{"10.250.0.5", "10.250.0.6"}},
+				}
+
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(ContainSubstring(`dhcp_generic_option {
+    code   = "43"
+    values = ["01:04:0a:fa:00:05"]
+  }`))
+				Expect(files.Main).To(ContainSubstring(`dhcp_generic_option {
+    code   = "42"
+    values = ["10.250.0.5", "10.250.0.6"]
+  }`))
+			})
+		})
+
+		Context("with overwritten chart path", func() {
+			var chartDir string
+
+			BeforeEach(func() {
+				var err error
+				chartDir, err = ioutil.TempDir("", "vsphere-infra")
+				Expect(err).NotTo(HaveOccurred())
+
+				files := map[string]string{
+					"Chart.yaml":                 "apiVersion: v1\nname: vsphere-infra\nversion: 0.1.0\n",
+					"templates/main.tf":          "# host {{ .Values.nsxt.host }}\n",
+					"templates/variables.tf":     "# cluster {{ .Values.clusterName }}\n",
+					"templates/terraform.tfvars": "# worker {{ .Values.networks.worker }}\n",
+				}
+				for name, content := range files {
+					path := filepath.Join(chartDir, name)
+					Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+					Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+				}
+				Expect(os.Setenv(TerraformChartOverwriteEnv, chartDir)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(chartDir)).To(Succeed())
+			})
+
+			It("should render the chart from the overwritten chart path", func() {
+				files, err := RenderTerraformerChart(renderer, infra, config, cloudProfileConfig, shoot, ChartOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files.Main).To(Equal("# host nsxt.host.internal\n"))
+				Expect(files.Variables).To(Equal("# cluster foo\n"))
+				Expect(files.TFVars).To(Equal([]byte("# worker 10.1.0.0/16\n")))
+			})
+		})
+	})
+
+	Describe("#CheckDHCPGateway", func() {
+		It("should succeed if the DHCP gateway is the downlink IP address of the router", func() {
+			tf := &fakeTerraformer{outputs: map[string]string{
+				TerraformOutputKeyDHCPGatewayIP:    "10.250.0.1",
+				TerraformOutputKeyRouterDownlinkIP: "10.250.0.1/24",
+			}}
+
+			Expect(CheckDHCPGateway(tf, nil)).To(Succeed())
+		})
+
+		It("should fail if the DHCP gateway differs from the downlink IP address of the router", func() {
+			tf := &fakeTerraformer{outputs: map[string]string{
+				TerraformOutputKeyDHCPGatewayIP:    "10.250.0.1",
+				TerraformOutputKeyRouterDownlinkIP: "10.250.0.254/24",
+			}}
+
+			Expect(CheckDHCPGateway(tf, nil)).To(MatchError("DHCP gateway 10.250.0.1 differs from the downlink IP address 10.250.0.254/24 of the T1 router"))
+		})
+
+		It("should compare the DHCP gateway with the client gateway if configured", func() {
+			tf := &fakeTerraformer{outputs: map[string]string{
+				TerraformOutputKeyDHCPGatewayIP:    "10.250.0.6",
+				TerraformOutputKeyRouterDownlinkIP: "10.250.0.1/24",
+			}}
+			clientGateway := "10.250.0.6"
+
+			Expect(CheckDHCPGateway(tf, &clientGateway)).To(Succeed())
+			Expect(CheckDHCPGateway(tf, nil)).To(MatchError("DHCP gateway 10.250.0.6 differs from the downlink IP address 10.250.0.1/24 of the T1 router"))
+
+			clientGateway = "10.250.0.7"
+			Expect(CheckDHCPGateway(tf, &clientGateway)).To(MatchError("DHCP gateway 10.250.0.6 differs from the configured client gateway 10.250.0.7"))
+		})
+
+		It("should succeed without DHCP server", func() {
+			tf := &fakeTerraformer{outputs: map[string]string{
+				TerraformOutputKeyRouterDownlinkIP: "10.250.0.1/24",
+			}}
+
+			Expect(CheckDHCPGateway(tf, nil)).To(Succeed())
+		})
+	})
+
+	Describe("#ComputeStatus", func() {
+		var tf terraformer.Terraformer
+
+		BeforeEach(func() {
+			tf = &fakeTerraformer{outputs: map[string]string{
+				TerraformOutputKeyNetworkName:     "network",
+				TerraformOutputKeyLogicalRouterId: "router-id",
+				TerraformOutputKeyLogicalSwitchId: "switch-id",
+			}}
+			cloudProfileConfig.Folder = "global-folder"
+		})
+
+		It("should use the global folder by default", func() {
+			status, err := ComputeStatus(tf, cloudProfileConfig, "testregion")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(status.Network).To(Equal("network"))
+			Expect(status.VsphereConfig.Folder).To(Equal("global-folder"))
+			Expect(status.VsphereConfig.ZoneConfigs["testzone"].Folder).To(BeEmpty())
+		})
+
+		It("should prefer the folder of the region", func() {
+			folder := "region-folder"
+			cloudProfileConfig.Regions[0].Folder = &folder
+
+			status, err := ComputeStatus(tf, cloudProfileConfig, "testregion")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(status.VsphereConfig.Folder).To(Equal("region-folder"))
+			Expect(status.VsphereConfig.ZoneConfigs["testzone"].Folder).To(BeEmpty())
+		})
+
+		It("should prefer the folder of the zone", func() {
+			regionFolder := "region-folder"
+			zoneFolder := "zone-folder"
+			cloudProfileConfig.Regions[0].Folder = &regionFolder
+			cloudProfileConfig.Regions[0].Zones[0].Folder = &zoneFolder
+
+			status, err := ComputeStatus(tf, cloudProfileConfig, "testregion")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(status.VsphereConfig.Folder).To(Equal("region-folder"))
+			Expect(status.VsphereConfig.ZoneConfigs["testzone"].Folder).To(Equal("zone-folder"))
+		})
+	})
 })
+
+type fakeTerraformer struct {
+	terraformer.Terraformer
+	outputs map[string]string
+}
+
+func (f *fakeTerraformer) GetStateOutputVariables(variables ...string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, v := range variables {
+		result[v] = f.outputs[v]
+	}
+	return result, nil
+}
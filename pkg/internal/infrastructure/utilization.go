@@ -0,0 +1,113 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+)
+
+// DefaultDHCPPoolPollInterval is the default interval in which the utilization of the DHCP IP pools is read.
+const DefaultDHCPPoolPollInterval = 5 * time.Minute
+
+// DHCPPoolUtilizationChange describes a crossed utilization level of a DHCP IP pool.
+type DHCPPoolUtilizationChange struct {
+	// Level is the crossed utilization level in percent.
+	Level int
+	// Rising is true if the level was crossed upwards, and false if it was crossed downwards.
+	Rising bool
+	// Usage are the usage statistics of the DHCP IP pool.
+	Usage nsxt.DHCPIPPoolUsage
+}
+
+// DHCPPoolUtilizationTracker tracks the utilization of DHCP IP pools and detects crossed utilization levels.
+// A level is only considered to be crossed downwards if the utilization falls below the level minus the
+// hysteresis, so that a utilization oscillating around a level does not flap.
+type DHCPPoolUtilizationTracker struct {
+	levels     []int
+	hysteresis int
+
+	lock    sync.Mutex
+	crossed map[string]int
+}
+
+// NewDHCPPoolUtilizationTracker creates a new DHCPPoolUtilizationTracker for the given levels in percent.
+func NewDHCPPoolUtilizationTracker(levels []int, hysteresis int) *DHCPPoolUtilizationTracker {
+	sorted := append([]int{}, levels...)
+	sort.Ints(sorted)
+	return &DHCPPoolUtilizationTracker{
+		levels:     sorted,
+		hysteresis: hysteresis,
+		crossed:    map[string]int{},
+	}
+}
+
+// Observe reads the usage statistics of the DHCP IP pool with the given ids and returns the crossed level, if any.
+// The given key identifies the pool in the tracker. If the statistics cannot be read, the tracked
+// utilization is left unchanged.
+func (t *DHCPPoolUtilizationTracker) Observe(ctx context.Context, client *nsxt.Client, key, serverID, poolID string) (*DHCPPoolUtilizationChange, error) {
+	usage, err := client.GetDHCPIPPoolUsage(ctx, serverID, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	level, rising, ok := t.update(key, usage.AllocatedPercentage)
+	if !ok {
+		return nil, nil
+	}
+	return &DHCPPoolUtilizationChange{Level: level, Rising: rising, Usage: *usage}, nil
+}
+
+// Forget removes the pool with the given key from the tracker.
+func (t *DHCPPoolUtilizationTracker) Forget(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.crossed, key)
+}
+
+// update records the utilization of the pool with the given key. It returns the highest level crossed upwards
+// or the lowest level crossed downwards together with the direction, and whether a level was crossed at all.
+func (t *DHCPPoolUtilizationTracker) update(key string, percentage float64) (int, bool, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	previous, ok := t.crossed[key]
+	if !ok {
+		previous = -1
+	}
+
+	current := previous
+	for current+1 < len(t.levels) && percentage >= float64(t.levels[current+1]) {
+		current++
+	}
+	for current >= 0 && percentage < float64(t.levels[current]-t.hysteresis) {
+		current--
+	}
+	t.crossed[key] = current
+
+	switch {
+	case current > previous:
+		return t.levels[current], true, true
+	case current < previous:
+		return t.levels[current+1], false, true
+	default:
+		return 0, false, false
+	}
+}
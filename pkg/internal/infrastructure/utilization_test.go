@@ -0,0 +1,115 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DHCPPoolUtilizationTracker", func() {
+	var (
+		ctx        = context.TODO()
+		server     *httptest.Server
+		client     *nsxt.Client
+		tracker    *DHCPPoolUtilizationTracker
+		percentage float64
+		available  bool
+	)
+
+	BeforeEach(func() {
+		available = true
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !available {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"pool_size": 100, "allocated_number": %d, "allocated_percentage": %g}`, int(percentage), percentage)))
+		}))
+		client = nsxt.NewClient(server.URL, "user", "password", true)
+		tracker = NewDHCPPoolUtilizationTracker([]int{90, 80}, 5)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	observe := func(p float64) *DHCPPoolUtilizationChange {
+		percentage = p
+		change, err := tracker.Observe(ctx, client, "shoot--foo--bar", "server", "pool")
+		Expect(err).NotTo(HaveOccurred())
+		return change
+	}
+
+	It("should report levels crossed upwards", func() {
+		Expect(observe(50)).To(BeNil())
+		Expect(observe(82)).To(Equal(&DHCPPoolUtilizationChange{
+			Level:  80,
+			Rising: true,
+			Usage:  nsxt.DHCPIPPoolUsage{PoolSize: 100, AllocatedNumber: 82, AllocatedPercentage: 82},
+		}))
+		Expect(observe(85)).To(BeNil())
+		Expect(observe(95).Level).To(Equal(90))
+	})
+
+	It("should report the highest level if several levels are crossed upwards", func() {
+		change := observe(91)
+		Expect(change.Level).To(Equal(90))
+		Expect(change.Rising).To(BeTrue())
+	})
+
+	It("should only report levels crossed downwards below the hysteresis", func() {
+		Expect(observe(92).Level).To(Equal(90))
+		Expect(observe(88)).To(BeNil())
+		Expect(observe(91)).To(BeNil())
+
+		change := observe(84)
+		Expect(change.Level).To(Equal(90))
+		Expect(change.Rising).To(BeFalse())
+
+		change = observe(10)
+		Expect(change.Level).To(Equal(80))
+		Expect(change.Rising).To(BeFalse())
+		Expect(observe(20)).To(BeNil())
+	})
+
+	It("should keep the tracked utilization if the statistics are unavailable", func() {
+		Expect(observe(85).Level).To(Equal(80))
+
+		available = false
+		change, err := tracker.Observe(ctx, client, "shoot--foo--bar", "server", "pool")
+		Expect(err).To(HaveOccurred())
+		Expect(change).To(BeNil())
+
+		available = true
+		Expect(observe(86)).To(BeNil())
+	})
+
+	It("should start over for forgotten pools", func() {
+		Expect(observe(85).Level).To(Equal(80))
+
+		tracker.Forget("shoot--foo--bar")
+
+		Expect(observe(85).Level).To(Equal(80))
+	})
+})
@@ -0,0 +1,275 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener-extension-provider-vsphere/pkg/version"
+)
+
+// Client is a minimal client for the REST API of the NSX-T manager.
+type Client struct {
+	baseURL     string
+	readBaseURL string
+	username    string
+	password    string
+	userAgent   string
+	dialer      *net.Dialer
+	httpClient  *http.Client
+
+	// globalManagerLock guards globalManager, which is nil until the kind of the NSX-T host is known.
+	globalManagerLock sync.Mutex
+	globalManager     *bool
+}
+
+// Timeouts are the timeouts of the requests to the NSX-T manager.
+type Timeouts struct {
+	// Dial is the maximum time to establish a TCP connection, so that unreachable managers fail fast.
+	Dial time.Duration
+	// TLSHandshake is the maximum time of the TLS handshake.
+	TLSHandshake time.Duration
+	// Request is the maximum time of a request including reading the response, which may be long for
+	// reads of the realization state.
+	Request time.Duration
+}
+
+// DefaultTimeouts are the timeouts of clients if not configured otherwise.
+var DefaultTimeouts = Timeouts{
+	Dial:         10 * time.Second,
+	TLSHandshake: 10 * time.Second,
+	Request:      30 * time.Second,
+}
+
+// DefaultUserAgent returns the User-Agent header sent by clients if not configured otherwise.
+func DefaultUserAgent() string {
+	return "gardener-extension-provider-vsphere/" + version.Version
+}
+
+// NewClient creates a new Client for the NSX-T manager on the given host.
+func NewClient(host, username, password string, insecureSSL bool) *Client {
+	dialer := &net.Dialer{Timeout: DefaultTimeouts.Dial}
+	return &Client{
+		baseURL:   toBaseURL(host),
+		username:  username,
+		password:  password,
+		userAgent: DefaultUserAgent(),
+		dialer:    dialer,
+		httpClient: &http.Client{
+			Timeout: DefaultTimeouts.Request,
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: DefaultTimeouts.TLSHandshake,
+				TLSClientConfig:     &tls.Config{InsecureSkipVerify: insecureSSL},
+			},
+		},
+	}
+}
+
+// WithReadHost lets the client send its requests to the given secondary NSX-T manager node, e.g. to offload the
+// primary one. If the secondary is unreachable, requests fall back to the primary.
+func (c *Client) WithReadHost(host string) *Client {
+	c.readBaseURL = toBaseURL(host)
+	return c
+}
+
+// WithUserAgent lets the client send the given User-Agent header, e.g. to attribute its requests in the audit log
+// of the NSX-T manager.
+func (c *Client) WithUserAgent(userAgent string) *Client {
+	c.userAgent = userAgent
+	return c
+}
+
+// WithTimeouts lets the client use the given timeouts. Zero timeouts keep the DefaultTimeouts.
+func (c *Client) WithTimeouts(timeouts Timeouts) *Client {
+	if timeouts.Dial > 0 {
+		c.dialer.Timeout = timeouts.Dial
+	}
+	if timeouts.TLSHandshake > 0 {
+		c.httpClient.Transport.(*http.Transport).TLSHandshakeTimeout = timeouts.TLSHandshake
+	}
+	if timeouts.Request > 0 {
+		c.httpClient.Timeout = timeouts.Request
+	}
+	return c
+}
+
+func toBaseURL(host string) string {
+	baseURL := host
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return strings.TrimSuffix(baseURL, "/")
+}
+
+// APIError is returned if the NSX-T manager responds with an unexpected HTTP status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by the NSX-T manager.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("NSX-T API request failed with HTTP status code %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if the given error is an APIError with HTTP status code 404.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ErrGlobalManager is returned if the NSX-T host is the Global Manager of an NSX-T Federation,
+// which does not serve the Management Plane API.
+var ErrGlobalManager = errors.New("NSX-T Global Manager is not supported, the NSX-T host must be a Local Manager")
+
+// globalManagerPath is only served by NSX-T Global Managers.
+const globalManagerPath = "/global-manager/api/v1/global-infra"
+
+const (
+	// maxRetries is the maximum number of retries of requests rejected because of rate limiting.
+	maxRetries = 5
+	// retryMinDelay is the delay before the first retry if the NSX-T manager does not specify it.
+	retryMinDelay = 500 * time.Millisecond
+	// retryMaxDelay is the maximum delay between retries if the NSX-T manager does not specify it.
+	retryMaxDelay = 5 * time.Second
+)
+
+// get performs a GET request on the given path and decodes the JSON response into out.
+// Requests rejected with HTTP status code 429 are retried, see waitForRetry.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		statusCode, header, body, err := c.do(ctx, path, query)
+		if err != nil {
+			return err
+		}
+		switch {
+		case statusCode == http.StatusOK:
+			return json.Unmarshal(body, out)
+		case statusCode == http.StatusTooManyRequests && attempt < maxRetries:
+			if err := waitForRetry(ctx, attempt, header.Get("Retry-After")); err != nil {
+				return err
+			}
+		case statusCode == http.StatusNotFound && c.isGlobalManager(ctx):
+			return ErrGlobalManager
+		default:
+			return newAPIError(statusCode, body)
+		}
+	}
+}
+
+// waitForRetry waits before the given retry attempt. It honors the delay in seconds of the given Retry-After header,
+// and uses an exponential backoff from retryMinDelay to retryMaxDelay otherwise.
+func waitForRetry(ctx context.Context, attempt int, retryAfter string) error {
+	delay := retryMinDelay << uint(attempt)
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isGlobalManager returns true if the NSX-T host is a Global Manager. The result is cached once the NSX-T host
+// answered, so that only the first not found response of a client is followed by a request of globalManagerPath.
+func (c *Client) isGlobalManager(ctx context.Context) bool {
+	c.globalManagerLock.Lock()
+	defer c.globalManagerLock.Unlock()
+
+	if c.globalManager == nil {
+		statusCode, _, _, err := c.do(ctx, globalManagerPath, nil)
+		if err != nil {
+			return false
+		}
+		globalManager := statusCode == http.StatusOK
+		c.globalManager = &globalManager
+	}
+	return *c.globalManager
+}
+
+// do performs a GET request on the given path and returns the status code, header and body of the response.
+// The request is sent to the read host if configured, and to the primary host if the read host is unreachable.
+func (c *Client) do(ctx context.Context, path string, query url.Values) (int, http.Header, []byte, error) {
+	if c.readBaseURL != "" {
+		statusCode, header, body, err := c.doOn(ctx, c.readBaseURL, path, query)
+		if err == nil || ctx.Err() != nil {
+			return statusCode, header, body, err
+		}
+	}
+	return c.doOn(ctx, c.baseURL, path, query)
+}
+
+// doOn performs a GET request on the given path of the given base URL.
+func (c *Client) doOn(ctx context.Context, baseURL, path string, query url.Values) (int, http.Header, []byte, error) {
+	u := baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req = req.WithContext(ctx)
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	var errorResponse struct {
+		ErrorMessage string `json:"error_message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.ErrorMessage != "" {
+		message = errorResponse.ErrorMessage
+	}
+	return &APIError{StatusCode: statusCode, Message: message}
+}
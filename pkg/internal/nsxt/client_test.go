@@ -0,0 +1,192 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		ctx           = context.TODO()
+		server        *httptest.Server
+		client        *Client
+		globalManager bool
+		gmRequests    int
+		rateLimited   int
+		requestTimes  []time.Time
+		userAgents    []string
+	)
+
+	BeforeEach(func() {
+		globalManager = false
+		gmRequests = 0
+		rateLimited = 0
+		requestTimes = nil
+		userAgents = nil
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgents = append(userAgents, r.UserAgent())
+			if r.URL.Path == "/api/v1/pools/ip-pools" {
+				requestTimes = append(requestTimes, time.Now())
+				if rateLimited > 0 {
+					rateLimited--
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"results": [{"id": "1", "display_name": "pool1"}]}`))
+				return
+			}
+			if r.URL.Path == "/global-manager/api/v1/global-infra" {
+				gmRequests++
+			}
+			if r.URL.Path == "/global-manager/api/v1/global-infra" && globalManager {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": "infra"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_message": "not found"}`))
+		}))
+		client = NewClient(server.URL, "user", "password", true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return a clear error for Global Managers", func() {
+		globalManager = true
+
+		_, err := client.ListTransportZones(ctx)
+		Expect(err).To(Equal(ErrGlobalManager))
+	})
+
+	It("should return the not found error for Local Managers", func() {
+		_, err := client.ListTransportZones(ctx)
+		Expect(IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should detect a Global Manager only once", func() {
+		for i := 0; i < 3; i++ {
+			_, err := client.ListTransportZones(ctx)
+			Expect(IsNotFound(err)).To(BeTrue())
+		}
+		Expect(gmRequests).To(Equal(1))
+	})
+
+	It("should retry rate limited requests after the requested delay", func() {
+		rateLimited = 1
+
+		pools, err := client.ListIPPools(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pools).To(HaveLen(1))
+		Expect(requestTimes).To(HaveLen(2))
+		Expect(requestTimes[1].Sub(requestTimes[0])).To(BeNumerically(">=", time.Second))
+	})
+
+	It("should stop retrying rate limited requests if the context is done", func() {
+		rateLimited = 10
+		cancelCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.ListIPPools(cancelCtx)
+		Expect(err).To(Equal(context.DeadlineExceeded))
+		Expect(requestTimes).To(HaveLen(1))
+	})
+
+	It("should send the default User-Agent header", func() {
+		_, err := client.ListIPPools(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(userAgents).To(ConsistOf(DefaultUserAgent()))
+		Expect(DefaultUserAgent()).To(HavePrefix("gardener-extension-provider-vsphere/"))
+	})
+
+	It("should send the configured User-Agent header", func() {
+		client.WithUserAgent("my-agent/1.0 (garden dev)")
+
+		_, err := client.ListIPPools(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(userAgents).To(ConsistOf("my-agent/1.0 (garden dev)"))
+	})
+
+	It("should use the default timeouts", func() {
+		Expect(client.Timeouts()).To(Equal(DefaultTimeouts))
+	})
+
+	It("should use the configured timeouts", func() {
+		client.WithTimeouts(Timeouts{Dial: 1 * time.Second, TLSHandshake: 2 * time.Second, Request: 3 * time.Minute})
+		Expect(client.Timeouts()).To(Equal(Timeouts{Dial: 1 * time.Second, TLSHandshake: 2 * time.Second, Request: 3 * time.Minute}))
+
+		client.WithTimeouts(Timeouts{Request: 1 * time.Minute})
+		Expect(client.Timeouts()).To(Equal(Timeouts{Dial: 1 * time.Second, TLSHandshake: 2 * time.Second, Request: 1 * time.Minute}))
+	})
+
+	It("should fail fast if the connection cannot be established", func() {
+		client = NewClient("https://10.255.255.1", "user", "password", true).WithTimeouts(Timeouts{Dial: 100 * time.Millisecond})
+
+		start := time.Now()
+		_, err := client.ListIPPools(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	Context("with read host", func() {
+		var (
+			readServer   *httptest.Server
+			readRequests int
+		)
+
+		BeforeEach(func() {
+			readRequests = 0
+			readServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				readRequests++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"results": [{"id": "2", "display_name": "pool2"}]}`))
+			}))
+			client.WithReadHost(readServer.URL)
+		})
+
+		AfterEach(func() {
+			readServer.Close()
+		})
+
+		It("should send requests to the read host", func() {
+			pools, err := client.ListIPPools(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(ConsistOf(IPPool{ID: "2", DisplayName: "pool2"}))
+			Expect(readRequests).To(Equal(1))
+			Expect(requestTimes).To(BeEmpty())
+		})
+
+		It("should fall back to the primary host if the read host is unreachable", func() {
+			readServer.Close()
+
+			pools, err := client.ListIPPools(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(ConsistOf(IPPool{ID: "1", DisplayName: "pool1"}))
+			Expect(requestTimes).To(HaveLen(1))
+		})
+	})
+})
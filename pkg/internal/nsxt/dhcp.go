@@ -0,0 +1,84 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// DHCPIPPoolUsage contains the usage statistics of an IP pool of a logical DHCP server.
+type DHCPIPPoolUsage struct {
+	// PoolSize is the number of addresses in the pool.
+	PoolSize int64 `json:"pool_size"`
+	// AllocatedNumber is the number of allocated addresses.
+	AllocatedNumber int64 `json:"allocated_number"`
+	// AllocatedPercentage is the percentage of allocated addresses.
+	AllocatedPercentage float64 `json:"allocated_percentage"`
+}
+
+// GetDHCPIPPoolUsage returns the usage statistics of the IP pool with the given id of the logical DHCP server with the given id.
+func (c *Client) GetDHCPIPPoolUsage(ctx context.Context, serverID, poolID string) (*DHCPIPPoolUsage, error) {
+	usage := &DHCPIPPoolUsage{}
+	path := fmt.Sprintf("/api/v1/dhcp/servers/%s/ip-pools/%s/statistics", url.PathEscape(serverID), url.PathEscape(poolID))
+	if err := c.get(ctx, path, nil, usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// ErrDHCPLeasesNotAvailable is returned if the NSX-T manager does not serve the leases of an existing logical DHCP server,
+// e.g. because the DHCP server is not realized on an edge node yet.
+var ErrDHCPLeasesNotAvailable = errors.New("the leases of the DHCP server are not available")
+
+// DHCPLease is an active lease of a logical DHCP server.
+type DHCPLease struct {
+	// IPAddress is the leased IP address.
+	IPAddress string `json:"ip_address"`
+	// MACAddress is the MAC address of the client.
+	MACAddress string `json:"mac_address"`
+	// HostName is the host name sent by the client, i.e. usually the name of the node.
+	HostName string `json:"host_name,omitempty"`
+	// Subnet is the subnet of the leased IP address.
+	Subnet string `json:"subnet,omitempty"`
+	// LeaseTime is the lease time in seconds.
+	LeaseTime string `json:"lease_time,omitempty"`
+	// StartTime is the start time of the lease.
+	StartTime string `json:"start_time,omitempty"`
+	// ExpireTime is the expiry time of the lease.
+	ExpireTime string `json:"expire_time,omitempty"`
+	// BindingState is the state of the lease, e.g. "active".
+	BindingState string `json:"binding_state,omitempty"`
+}
+
+// GetDHCPLeases returns the active leases of the logical DHCP server with the given id.
+func (c *Client) GetDHCPLeases(ctx context.Context, serverID string) ([]DHCPLease, error) {
+	var result struct {
+		Leases []DHCPLease `json:"leases"`
+	}
+	serverPath := fmt.Sprintf("/api/v1/dhcp/servers/%s", url.PathEscape(serverID))
+	if err := c.get(ctx, serverPath+"/leases", nil, &result); err != nil {
+		if !IsNotFound(err) {
+			return nil, err
+		}
+		if _, serverErr := c.GetObject(ctx, serverPath); serverErr != nil {
+			return nil, serverErr
+		}
+		return nil, ErrDHCPLeasesNotAvailable
+	}
+	return result.Leases, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DHCP", func() {
+	var (
+		ctx    = context.TODO()
+		server *httptest.Server
+		client *Client
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v1/dhcp/servers/server-1/ip-pools/pool-1/statistics":
+				_, _ = w.Write([]byte(`{"dhcp_server_id": "server-1", "dhcp_ip_pool_id": "pool-1", "pool_size": 200, "allocated_number": 50, "allocated_percentage": 25}`))
+			case "/api/v1/dhcp/servers/server-1/leases":
+				_, _ = w.Write([]byte(`{"dhcp_server_id": "server-1", "leases": [
+					{"ip_address": "10.1.0.10", "mac_address": "00:50:56:00:00:01", "host_name": "shoot--foo--bar-worker-1", "subnet": "10.1.0.0/16", "lease_time": "86400", "expire_time": "2020-06-02 10:00:00", "binding_state": "active"},
+					{"ip_address": "10.1.0.11", "mac_address": "00:50:56:00:00:02", "subnet": "10.1.0.0/16", "lease_time": "86400", "expire_time": "2020-06-02 11:00:00", "binding_state": "active"}
+				]}`))
+			case "/api/v1/dhcp/servers/server-1", "/api/v1/dhcp/servers/server-2":
+				_, _ = w.Write([]byte(`{"id": "server"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_message": "not found"}`))
+			}
+		}))
+		client = NewClient(server.URL, "user", "password", true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#GetDHCPIPPoolUsage", func() {
+		It("should return the usage statistics of the pool", func() {
+			usage, err := client.GetDHCPIPPoolUsage(ctx, "server-1", "pool-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(&DHCPIPPoolUsage{PoolSize: 200, AllocatedNumber: 50, AllocatedPercentage: 25}))
+		})
+
+		It("should return a not found error for unknown pools", func() {
+			_, err := client.GetDHCPIPPoolUsage(ctx, "server-1", "pool-2")
+			Expect(IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("#GetDHCPLeases", func() {
+		It("should return the leases of the server", func() {
+			leases, err := client.GetDHCPLeases(ctx, "server-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leases).To(Equal([]DHCPLease{
+				{IPAddress: "10.1.0.10", MACAddress: "00:50:56:00:00:01", HostName: "shoot--foo--bar-worker-1", Subnet: "10.1.0.0/16", LeaseTime: "86400", ExpireTime: "2020-06-02 10:00:00", BindingState: "active"},
+				{IPAddress: "10.1.0.11", MACAddress: "00:50:56:00:00:02", Subnet: "10.1.0.0/16", LeaseTime: "86400", ExpireTime: "2020-06-02 11:00:00", BindingState: "active"},
+			}))
+		})
+
+		It("should return a clear error if the leases are not available", func() {
+			_, err := client.GetDHCPLeases(ctx, "server-2")
+			Expect(err).To(Equal(ErrDHCPLeasesNotAvailable))
+		})
+
+		It("should return a not found error for unknown servers", func() {
+			_, err := client.GetDHCPLeases(ctx, "server-3")
+			Expect(IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,120 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// EdgeClusterMemberStatusUp is the status of an edge cluster member which is up.
+const EdgeClusterMemberStatusUp = "UP"
+
+// EdgeCluster is an NSX-T edge cluster.
+type EdgeCluster struct {
+	// ID is the identifier of the edge cluster.
+	ID string `json:"id"`
+	// DisplayName is the display name of the edge cluster.
+	DisplayName string `json:"display_name"`
+	// Members are the edge nodes of the edge cluster.
+	Members []EdgeClusterMember `json:"members"`
+}
+
+// EdgeClusterMember is an edge node of an edge cluster.
+type EdgeClusterMember struct {
+	// MemberIndex is the index of the member in the edge cluster.
+	MemberIndex int `json:"member_index"`
+	// TransportNodeID is the identifier of the transport node of the member.
+	TransportNodeID string `json:"transport_node_id"`
+}
+
+// EdgeClusterStatus is the status of an edge cluster.
+type EdgeClusterStatus struct {
+	// EdgeClusterStatus is the overall status of the edge cluster.
+	EdgeClusterStatus string `json:"edge_cluster_status"`
+	// MemberStatus is the status of the members of the edge cluster.
+	MemberStatus []EdgeClusterMemberStatus `json:"member_status"`
+}
+
+// EdgeClusterMemberStatus is the status of an edge cluster member.
+type EdgeClusterMemberStatus struct {
+	// TransportNodeID is the identifier of the transport node of the member.
+	TransportNodeID string `json:"transport_node_id"`
+	// Status is the status of the member.
+	Status string `json:"status"`
+}
+
+type edgeClusterListResult struct {
+	Results []EdgeCluster `json:"results"`
+	Cursor  string        `json:"cursor"`
+}
+
+// ListEdgeClusters lists all edge clusters.
+func (c *Client) ListEdgeClusters(ctx context.Context) ([]EdgeCluster, error) {
+	var clusters []EdgeCluster
+	query := url.Values{}
+	for {
+		result := &edgeClusterListResult{}
+		if err := c.get(ctx, "/api/v1/edge-clusters", query, result); err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, result.Results...)
+		if result.Cursor == "" {
+			return clusters, nil
+		}
+		query.Set("cursor", result.Cursor)
+	}
+}
+
+// FindEdgeClusterByName returns the edge cluster with the given display name. It fails if the name is not unique.
+func (c *Client) FindEdgeClusterByName(ctx context.Context, name string) (*EdgeCluster, error) {
+	clusters, err := c.ListEdgeClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found *EdgeCluster
+	for i, cluster := range clusters {
+		if cluster.DisplayName != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("edge cluster name %q is ambiguous (ids %s and %s)", name, found.ID, cluster.ID)
+		}
+		found = &clusters[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("edge cluster %q not found", name)
+	}
+	return found, nil
+}
+
+// GetEdgeCluster returns the edge cluster with the given id.
+func (c *Client) GetEdgeCluster(ctx context.Context, id string) (*EdgeCluster, error) {
+	cluster := &EdgeCluster{}
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/edge-clusters/%s", url.PathEscape(id)), nil, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// GetEdgeClusterStatus returns the status of the edge cluster with the given id.
+func (c *Client) GetEdgeClusterStatus(ctx context.Context, id string) (*EdgeClusterStatus, error) {
+	status := &EdgeClusterStatus{}
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/edge-clusters/%s/status", url.PathEscape(id)), nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
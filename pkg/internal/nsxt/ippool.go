@@ -0,0 +1,95 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// IPPool is an NSX-T IP pool.
+type IPPool struct {
+	// ID is the identifier of the IP pool.
+	ID string `json:"id"`
+	// DisplayName is the display name of the IP pool.
+	DisplayName string `json:"display_name"`
+	// PoolUsage contains the usage statistics of the IP pool.
+	PoolUsage IPPoolUsage `json:"pool_usage"`
+	// Subnets are the subnets of the IP pool.
+	Subnets []IPPoolSubnet `json:"subnets,omitempty"`
+}
+
+// IPPoolSubnet is a subnet of an IP pool.
+type IPPoolSubnet struct {
+	// CIDR is the network address of the subnet.
+	CIDR string `json:"cidr"`
+	// AllocationRanges are the address ranges of the subnet addresses are allocated from.
+	AllocationRanges []IPPoolRange `json:"allocation_ranges"`
+}
+
+// IPPoolRange is an inclusive range of addresses.
+type IPPoolRange struct {
+	// Start is the first address of the range.
+	Start string `json:"start"`
+	// End is the last address of the range.
+	End string `json:"end"`
+}
+
+// IPPoolUsage contains the usage statistics of an IP pool.
+type IPPoolUsage struct {
+	// TotalIDs is the total number of addresses in the pool.
+	TotalIDs int64 `json:"total_ids"`
+	// AllocatedIDs is the number of allocated addresses.
+	AllocatedIDs int64 `json:"allocated_ids"`
+	// FreeIDs is the number of free addresses.
+	FreeIDs int64 `json:"free_ids"`
+}
+
+type ipPoolListResult struct {
+	Results []IPPool `json:"results"`
+	Cursor  string   `json:"cursor"`
+}
+
+// ListIPPools lists all IP pools.
+func (c *Client) ListIPPools(ctx context.Context) ([]IPPool, error) {
+	var pools []IPPool
+	query := url.Values{}
+	for {
+		result := &ipPoolListResult{}
+		if err := c.get(ctx, "/api/v1/pools/ip-pools", query, result); err != nil {
+			return nil, err
+		}
+		pools = append(pools, result.Results...)
+		if result.Cursor == "" {
+			return pools, nil
+		}
+		query.Set("cursor", result.Cursor)
+	}
+}
+
+// FindIPPoolByName returns the IP pool with the given display name.
+func (c *Client) FindIPPoolByName(ctx context.Context, name string) (*IPPool, error) {
+	pools, err := c.ListIPPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		if pool.DisplayName == name {
+			return &pool, nil
+		}
+	}
+	return nil, fmt.Errorf("IP pool %q not found", name)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IPPool", func() {
+	var (
+		ctx    = context.TODO()
+		server *httptest.Server
+		client *Client
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "password" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error_message": "not authorized"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Query().Get("cursor") {
+			case "":
+				_, _ = w.Write([]byte(`{"results": [{"id": "1", "display_name": "pool1"}], "cursor": "next"}`))
+			case "next":
+				_, _ = w.Write([]byte(`{"results": [{"id": "2", "display_name": "pool2", "pool_usage": {"total_ids": 5, "allocated_ids": 2, "free_ids": 3},
+					"subnets": [{"cidr": "10.0.0.0/24", "allocation_ranges": [{"start": "10.0.0.10", "end": "10.0.0.14"}]}]}]}`))
+			}
+		}))
+		client = NewClient(server.URL, "user", "password", true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#ListIPPools", func() {
+		It("should list the IP pools of all pages", func() {
+			pools, err := client.ListIPPools(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(Equal([]IPPool{
+				{ID: "1", DisplayName: "pool1"},
+				{ID: "2", DisplayName: "pool2", PoolUsage: IPPoolUsage{TotalIDs: 5, AllocatedIDs: 2, FreeIDs: 3},
+					Subnets: []IPPoolSubnet{{CIDR: "10.0.0.0/24", AllocationRanges: []IPPoolRange{{Start: "10.0.0.10", End: "10.0.0.14"}}}}},
+			}))
+		})
+
+		It("should return an API error on unexpected status codes", func() {
+			client = NewClient(server.URL, "user", "wrong", true)
+
+			_, err := client.ListIPPools(ctx)
+			Expect(err).To(Equal(&APIError{StatusCode: http.StatusForbidden, Message: "not authorized"}))
+		})
+	})
+
+	Describe("#FindIPPoolByName", func() {
+		It("should find the IP pool by its display name", func() {
+			pool, err := client.FindIPPoolByName(ctx, "pool2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.ID).To(Equal("2"))
+		})
+
+		It("should fail if no IP pool has the display name", func() {
+			_, err := client.FindIPPoolByName(ctx, "pool3")
+			Expect(err).To(MatchError(`IP pool "pool3" not found`))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"net/url"
+)
+
+// GetObject returns the raw JSON representation of the object on the given API path.
+func (c *Client) GetObject(ctx context.Context, path string) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	if err := c.get(ctx, path, nil, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// ListObjects returns the raw JSON representations of all objects listed on the given API path.
+func (c *Client) ListObjects(ctx context.Context, path string, query url.Values) ([]map[string]interface{}, error) {
+	var objects []map[string]interface{}
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	for {
+		var result struct {
+			Results []map[string]interface{} `json:"results"`
+			Cursor  string                   `json:"cursor"`
+		}
+		if err := c.get(ctx, path, q, &result); err != nil {
+			return nil, err
+		}
+		objects = append(objects, result.Results...)
+		if result.Cursor == "" {
+			return objects, nil
+		}
+		q.Set("cursor", result.Cursor)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ReferenceKind is the kind of an NSX-T object referenced by id.
+type ReferenceKind string
+
+const (
+	// ReferenceKindLogicalRouter is the kind of logical routers.
+	ReferenceKindLogicalRouter ReferenceKind = "LogicalRouter"
+	// ReferenceKindLogicalSwitch is the kind of logical switches.
+	ReferenceKindLogicalSwitch ReferenceKind = "LogicalSwitch"
+	// ReferenceKindLogicalPort is the kind of logical ports.
+	ReferenceKindLogicalPort ReferenceKind = "LogicalPort"
+	// ReferenceKindDHCPServer is the kind of logical DHCP servers.
+	ReferenceKindDHCPServer ReferenceKind = "DHCPServer"
+	// ReferenceKindDHCPServerProfile is the kind of DHCP server profiles.
+	ReferenceKindDHCPServerProfile ReferenceKind = "DHCPServerProfile"
+	// ReferenceKindEdgeCluster is the kind of edge clusters.
+	ReferenceKindEdgeCluster ReferenceKind = "EdgeCluster"
+)
+
+// referencePaths are the API paths of the objects by kind.
+var referencePaths = map[ReferenceKind]string{
+	ReferenceKindLogicalRouter:     "/api/v1/logical-routers/%s",
+	ReferenceKindLogicalSwitch:     "/api/v1/logical-switches/%s",
+	ReferenceKindLogicalPort:       "/api/v1/logical-ports/%s",
+	ReferenceKindDHCPServer:        "/api/v1/dhcp/servers/%s",
+	ReferenceKindDHCPServerProfile: "/api/v1/dhcp/server-profiles/%s",
+	ReferenceKindEdgeCluster:       "/api/v1/edge-clusters/%s",
+}
+
+// Reference references an NSX-T object by kind and id.
+type Reference struct {
+	// Kind is the kind of the referenced object.
+	Kind ReferenceKind
+	// ID is the id of the referenced object.
+	ID string
+}
+
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s %q", r.Kind, r.ID)
+}
+
+// Resolve checks that the referenced object still exists by reading it. If the object does not exist anymore,
+// the returned error satisfies IsNotFound.
+func (r *Reference) Resolve(ctx context.Context, client *Client) error {
+	path, ok := referencePaths[r.Kind]
+	if !ok {
+		return fmt.Errorf("unknown kind of reference %s", r)
+	}
+	if r.ID == "" {
+		return fmt.Errorf("missing id of reference %s", r)
+	}
+	_, err := client.GetObject(ctx, fmt.Sprintf(path, url.PathEscape(r.ID)))
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/gardener-extension-provider-vsphere/pkg/internal/nsxt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reference", func() {
+	var (
+		ctx    = context.TODO()
+		server *httptest.Server
+		client *Client
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v1/logical-switches/switch-1":
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": "switch-1"}`))
+			case "/api/v1/dhcp/servers/server-1":
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"error_message": "service unavailable"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_message": "not found"}`))
+			}
+		}))
+		client = NewClient(server.URL, "user", "password", true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#Resolve", func() {
+		It("should succeed if the referenced object exists", func() {
+			ref := &Reference{Kind: ReferenceKindLogicalSwitch, ID: "switch-1"}
+			Expect(ref.Resolve(ctx, client)).To(Succeed())
+		})
+
+		It("should return a not found error if the referenced object does not exist", func() {
+			ref := &Reference{Kind: ReferenceKindLogicalSwitch, ID: "switch-2"}
+			err := ref.Resolve(ctx, client)
+			Expect(IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should return transient errors", func() {
+			ref := &Reference{Kind: ReferenceKindDHCPServer, ID: "server-1"}
+			err := ref.Resolve(ctx, client)
+			Expect(err).To(MatchError(&APIError{StatusCode: http.StatusServiceUnavailable, Message: "service unavailable"}))
+			Expect(IsNotFound(err)).To(BeFalse())
+		})
+
+		It("should fail for unknown kinds", func() {
+			ref := &Reference{Kind: "Unknown", ID: "foo"}
+			Expect(ref.Resolve(ctx, client)).To(MatchError(`unknown kind of reference Unknown "foo"`))
+		})
+	})
+})
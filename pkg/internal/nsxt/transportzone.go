@@ -0,0 +1,74 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	// TransportTypeOverlay is the transport type of overlay transport zones.
+	TransportTypeOverlay = "OVERLAY"
+	// TransportTypeVLAN is the transport type of VLAN backed transport zones.
+	TransportTypeVLAN = "VLAN"
+)
+
+// TransportZone is an NSX-T transport zone.
+type TransportZone struct {
+	// ID is the identifier of the transport zone.
+	ID string `json:"id"`
+	// DisplayName is the display name of the transport zone.
+	DisplayName string `json:"display_name"`
+	// TransportType is the transport type of the transport zone (OVERLAY or VLAN).
+	TransportType string `json:"transport_type"`
+}
+
+type transportZoneListResult struct {
+	Results []TransportZone `json:"results"`
+	Cursor  string          `json:"cursor"`
+}
+
+// ListTransportZones lists all transport zones.
+func (c *Client) ListTransportZones(ctx context.Context) ([]TransportZone, error) {
+	var zones []TransportZone
+	query := url.Values{}
+	for {
+		result := &transportZoneListResult{}
+		if err := c.get(ctx, "/api/v1/transport-zones", query, result); err != nil {
+			return nil, err
+		}
+		zones = append(zones, result.Results...)
+		if result.Cursor == "" {
+			return zones, nil
+		}
+		query.Set("cursor", result.Cursor)
+	}
+}
+
+// FindTransportZoneByName returns the transport zone with the given display name.
+func (c *Client) FindTransportZoneByName(ctx context.Context, name string) (*TransportZone, error) {
+	zones, err := c.ListTransportZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		if zone.DisplayName == name {
+			return &zone, nil
+		}
+	}
+	return nil, fmt.Errorf("transport zone %q not found", name)
+}